@@ -0,0 +1,72 @@
+package eventlogger
+
+import (
+	"context"
+	"time"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logstore"
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func watchedAddressToProtobuf(w logstore.WatchedAddress) *logger.WatchedAddress {
+	return &logger.WatchedAddress{
+		Address:    w.Address.Bytes(),
+		Label:      w.Label,
+		AddedBlock: w.AddedBlock,
+		AddedAt:    timestamppb.New(w.AddedAt),
+		Active:     w.Active,
+	}
+}
+
+// deactivateAddress 는 Remove 된 주소를 목록에서 지우지 않고 Active 를 false 로 저장한다.
+func (s *chainLogger) deactivateAddress(ctx context.Context, address common.Address) error {
+	list, err := s.store.LoadAddresses(ctx)
+	if err != nil {
+		return err
+	}
+	for _, w := range list {
+		if w.Address == address {
+			w.Active = false
+			return s.store.SaveAddress(ctx, w)
+		}
+	}
+	return nil
+}
+
+// initAddresses 는 저장된 주소 목록과 설정 파일의 주소 목록을 합쳐 수집할 주소를 정한다.
+// 저장된 적이 없는 설정 파일의 주소만 새로 저장하며, Admin.Remove 로 제외된 주소는 재시작 후에도 제외된다.
+func (s *chainLogger) initAddresses(ctx context.Context, configured []common.Address, fromBlock uint64) error {
+	list, err := s.store.LoadAddresses(ctx)
+	if err != nil {
+		return err
+	}
+	saved := make(map[common.Address]struct{}, len(list))
+	for _, w := range list {
+		saved[w.Address] = struct{}{}
+	}
+	for _, address := range configured {
+		if _, ok := saved[address]; ok {
+			continue
+		}
+		w := logstore.WatchedAddress{Address: address, AddedBlock: fromBlock, AddedAt: time.Now(), Active: true}
+		if err := s.store.SaveAddress(ctx, w); err != nil {
+			return err
+		}
+		saved[address] = struct{}{}
+		list = append(list, w)
+	}
+
+	s.qlock.Lock()
+	defer s.qlock.Unlock()
+	addresses := make([]common.Address, 0, len(list))
+	for _, w := range list {
+		if w.Active {
+			s.addrSet[w.Address] = struct{}{}
+			addresses = append(addresses, w.Address)
+		}
+	}
+	s.query.Addresses = addresses
+	return nil
+}
@@ -0,0 +1,476 @@
+package eventlogger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bang9ming9/bm-cli-tool/cmd/flags"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	uriFlag = &cli.StringFlag{
+		Name:  "uri",
+		Usage: "event-logger gRPC address of the called service (default: [client] uri or admin-uri, [server] host or admin-host)",
+	}
+	jsonFlag = &cli.BoolFlag{
+		Name:  "json",
+		Usage: "print the result as JSON",
+	}
+	adminChainIDFlag = &cli.Uint64Flag{
+		Name:  "chain-id",
+		Usage: "chain id (default: the default chain of the event-logger)",
+	}
+	labelFlag = &cli.StringFlag{
+		Name:  "label",
+		Usage: "description of the address",
+	}
+	backfillFlag = &cli.Uint64Flag{
+		Name:  "from",
+		Usage: "collect past logs of the address from this block in the background",
+	}
+)
+
+// adminFlags 는 admin 의 모든 명령이 사용하는 플래그에 extra 를 더한다.
+// 상위 명령의 플래그는 하위 명령에서 읽을 수 없기 때문에 하위 명령마다 설정한다.
+func adminFlags(extra ...cli.Flag) []cli.Flag {
+	return append([]cli.Flag{flags.ConfigFlag, uriFlag, jsonFlag}, extra...)
+}
+
+var adminCommand = &cli.Command{
+	Name:  "admin",
+	Usage: "Call the Admin service of a running event-logger",
+	Subcommands: []*cli.Command{
+		adminAddCommand,
+		adminRemoveCommand,
+		adminStartCommand,
+		adminStopCommand,
+		adminInfoCommand,
+		adminStatusCommand,
+	},
+}
+
+var adminAddCommand = &cli.Command{
+	Name:      "add",
+	Usage:     "Start collecting logs of an address",
+	ArgsUsage: "<address>",
+	Flags:     adminFlags(adminChainIDFlag, backfillFlag, labelFlag),
+	Action: func(ctx *cli.Context) error {
+		address, err := addressArg(ctx)
+		if err != nil {
+			return err
+		}
+		conn, err := dialLogger(ctx, true)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		res, err := logger.NewAdminClient(conn).Add(ctx.Context, &logger.AddressReqMessage{
+			Address:   address.Bytes(),
+			FromBlock: ctx.Uint64(backfillFlag.Name),
+			Label:     ctx.String(labelFlag.Name),
+			ChainId:   ctx.Uint64(adminChainIDFlag.Name),
+		})
+		if err != nil {
+			return err
+		}
+		return printResult(ctx, adminBlockNumber{ChainID: res.ChainId, Address: &address, BlockNumber: res.BlockNumber}, func(w io.Writer) {
+			fmt.Fprintf(w, "added %s at block %d (chain-id: %d)\n", address.Hex(), res.BlockNumber, res.ChainId)
+			if from := ctx.Uint64(backfillFlag.Name); from != 0 {
+				fmt.Fprintf(w, "collecting past logs from block %d in the background\n", from)
+			}
+		})
+	},
+}
+
+var adminRemoveCommand = &cli.Command{
+	Name:      "remove",
+	Usage:     "Stop collecting logs of an address, stored logs are kept",
+	ArgsUsage: "<address>",
+	Flags:     adminFlags(adminChainIDFlag),
+	Action: func(ctx *cli.Context) error {
+		address, err := addressArg(ctx)
+		if err != nil {
+			return err
+		}
+		conn, err := dialLogger(ctx, true)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		res, err := logger.NewAdminClient(conn).Remove(ctx.Context, &logger.AddressReqMessage{
+			Address: address.Bytes(),
+			ChainId: ctx.Uint64(adminChainIDFlag.Name),
+		})
+		if err != nil {
+			return err
+		}
+		return printResult(ctx, adminBlockNumber{ChainID: res.ChainId, Address: &address, BlockNumber: res.BlockNumber}, func(w io.Writer) {
+			fmt.Fprintf(w, "removed %s at block %d (chain-id: %d)\n", address.Hex(), res.BlockNumber, res.ChainId)
+		})
+	},
+}
+
+var adminStartCommand = &cli.Command{
+	Name:      "start",
+	Usage:     "Start collecting logs of a stopped chain",
+	ArgsUsage: "[block] (default: continue from the checkpoint)",
+	Flags:     adminFlags(adminChainIDFlag),
+	Action: func(ctx *cli.Context) error {
+		var block uint64
+		if arg := ctx.Args().First(); arg != "" {
+			var err error
+			if block, err = parseUint(arg); err != nil {
+				return fmt.Errorf("invalid block number: %s", arg)
+			}
+		}
+		conn, err := dialLogger(ctx, true)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		chainID := ctx.Uint64(adminChainIDFlag.Name)
+		if _, err := logger.NewAdminClient(conn).Start(ctx.Context, &logger.BlockNumberMessage{BlockNumber: block, ChainId: chainID}); err != nil {
+			return err
+		}
+		return printResult(ctx, adminBlockNumber{ChainID: chainID, BlockNumber: block}, func(w io.Writer) {
+			if block == 0 {
+				fmt.Fprintln(w, "started from the checkpoint")
+			} else {
+				fmt.Fprintf(w, "started from block %d\n", block)
+			}
+		})
+	},
+}
+
+var adminStopCommand = &cli.Command{
+	Name:      "stop",
+	Usage:     "Stop collecting logs and print the last collected block",
+	ArgsUsage: " ",
+	Flags:     adminFlags(adminChainIDFlag),
+	Action: func(ctx *cli.Context) error {
+		conn, err := dialLogger(ctx, true)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		res, err := logger.NewAdminClient(conn).Stop(ctx.Context, &logger.ChainReqMessage{ChainId: ctx.Uint64(adminChainIDFlag.Name)})
+		if err != nil {
+			return err
+		}
+		return printResult(ctx, adminBlockNumber{ChainID: res.ChainId, BlockNumber: res.BlockNumber}, func(w io.Writer) {
+			fmt.Fprintf(w, "stopped at block %d (chain-id: %d)\n", res.BlockNumber, res.ChainId)
+		})
+	},
+}
+
+var adminInfoCommand = &cli.Command{
+	Name:      "info",
+	Usage:     "Print the chains and the collecting addresses",
+	ArgsUsage: " ",
+	Flags:     adminFlags(),
+	Action: func(ctx *cli.Context) error {
+		conn, err := dialLogger(ctx, false)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		res, err := logger.NewLoggerClient(conn).Info(ctx.Context, &emptypb.Empty{})
+		if err != nil {
+			return err
+		}
+		// HTTP 의 /v1/info 와 같은 형식을 사용한다.
+		info := gatewayInfo{ChainID: res.ChainId, Address: addressesFromBytes(res.Address)}
+		for _, chain := range res.Chains {
+			info.Chains = append(info.Chains, gatewayChainInfo{ChainID: chain.ChainId, Address: addressesFromBytes(chain.Address)})
+		}
+		return printResult(ctx, info, func(w io.Writer) {
+			for i, chain := range info.Chains {
+				if i == 0 {
+					fmt.Fprintf(w, "chain-id: %d (default)\n", chain.ChainID)
+				} else {
+					fmt.Fprintf(w, "chain-id: %d\n", chain.ChainID)
+				}
+				for _, address := range chain.Address {
+					fmt.Fprintf(w, "  %s\n", address.Hex())
+				}
+			}
+		})
+	},
+}
+
+var adminStatusCommand = &cli.Command{
+	Name:      "status",
+	Usage:     "Print the chain head, the scanned block, addresses, clients and recent errors",
+	ArgsUsage: " ",
+	Flags:     adminFlags(adminChainIDFlag),
+	Action: func(ctx *cli.Context) error {
+		conn, err := dialLogger(ctx, true)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		res, err := logger.NewAdminClient(conn).Status(ctx.Context, &logger.ChainReqMessage{ChainId: ctx.Uint64(adminChainIDFlag.Name)})
+		if err != nil {
+			return err
+		}
+		status := statusFromProtobuf(res)
+		return printResult(ctx, status, status.print)
+	},
+}
+
+// dialLogger 는 --uri 또는 설정 파일의 event-logger 에 연결한다.
+// admin 이면 Admin 서비스의 주소에 인증 정보와 함께 연결하고, 아니면 Logger 서비스의 주소에 연결한다.
+func dialLogger(ctx *cli.Context, admin bool) (*grpc.ClientConn, error) {
+	config, err := flags.ReadConfig[Config](ctx)
+	if err != nil {
+		return nil, err
+	}
+	uri := config.ClientURI()
+	if admin {
+		uri = config.AdminClientURI()
+	}
+	if ctx.IsSet(uriFlag.Name) {
+		uri = ctx.String(uriFlag.Name)
+	}
+	if uri == "" {
+		return nil, errors.New("uri is not set, use --uri or [client] uri")
+	}
+	creds, err := config.NewClientCredentials()
+	if err != nil {
+		return nil, err
+	}
+	options := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if token := config.ClientToken(); admin && token != "" {
+		options = append(options, grpc.WithPerRPCCredentials(bearerToken{token: token, secure: creds.Info().SecurityProtocol == "tls"}))
+	}
+	return grpc.NewClient(uri, options...)
+}
+
+func addressArg(ctx *cli.Context) (common.Address, error) {
+	arg := ctx.Args().First()
+	if arg == "" {
+		return common.Address{}, errors.New("address is not set")
+	}
+	if !common.IsHexAddress(arg) {
+		return common.Address{}, fmt.Errorf("invalid address: %s", arg)
+	}
+	return common.HexToAddress(arg), nil
+}
+
+// printResult 는 --json 이면 value 를 JSON 으로, 아니면 text 로 표준 출력에 쓴다.
+func printResult(ctx *cli.Context, value interface{}, text func(w io.Writer)) error {
+	if ctx.Bool(jsonFlag.Name) {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	}
+	text(os.Stdout)
+	return nil
+}
+
+// admin 명령의 --json 출력 형식
+
+type adminBlockNumber struct {
+	ChainID     uint64          `json:"chainId,omitempty"` // start 는 요청한 체인 (0: 기본 체인)
+	Address     *common.Address `json:"address,omitempty"`
+	BlockNumber uint64          `json:"blockNumber"`
+}
+
+type adminStatus struct {
+	ChainID       uint64                `json:"chainId"`
+	HeadBlock     uint64                `json:"headBlock"`
+	ScanBlock     uint64                `json:"scanBlock"`
+	Lag           uint64                `json:"lag"`
+	Running       bool                  `json:"running"`
+	Confirmations uint64                `json:"confirmations"`
+	Addresses     []adminAddressStatus  `json:"addresses"`
+	Clients       []adminClientStatus   `json:"clients"`
+	Errors        []adminErrorStatus    `json:"errors"`
+	Endpoints     []adminEndpointStatus `json:"endpoints,omitempty"`
+	Webhooks      []adminWebhookStatus  `json:"webhooks,omitempty"`
+}
+
+type adminAddressStatus struct {
+	Address     common.Address `json:"address"`
+	Label       string         `json:"label,omitempty"`
+	Active      bool           `json:"active"`
+	AddedBlock  uint64         `json:"addedBlock"`
+	LogCount    uint64         `json:"logCount"`
+	Backfilling bool           `json:"backfilling,omitempty"`
+}
+
+type adminClientStatus struct {
+	ID            uint32           `json:"id"`
+	Addresses     []common.Address `json:"addresses"`
+	QueueLength   uint32           `json:"queueLength"`
+	QueueCapacity uint32           `json:"queueCapacity"`
+	Dropped       uint64           `json:"dropped"`
+	Decode        bool             `json:"decode"`
+	ConnectedAt   time.Time        `json:"connectedAt"`
+}
+
+type adminErrorStatus struct {
+	Time      time.Time `json:"time"`
+	Operation string    `json:"operation"`
+	Message   string    `json:"message"`
+}
+
+type adminEndpointStatus struct {
+	URI         string     `json:"uri"`
+	Active      bool       `json:"active"`
+	Healthy     bool       `json:"healthy"`
+	Failures    uint32     `json:"failures"`
+	LastError   string     `json:"lastError,omitempty"`
+	LastErrorAt *time.Time `json:"lastErrorAt,omitempty"`
+}
+
+type adminWebhookStatus struct {
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	BlockNumber uint64 `json:"blockNumber"` // 전달이 완료된 마지막 위치, 저장된 적이 없으면 0
+	Index       uint32 `json:"index"`
+}
+
+func statusFromProtobuf(res *logger.StatusResMessage) adminStatus {
+	status := adminStatus{
+		ChainID:       res.ChainId,
+		HeadBlock:     res.HeadBlock,
+		ScanBlock:     res.ScanBlock,
+		Lag:           res.Lag,
+		Running:       res.Running,
+		Confirmations: res.Confirmations,
+		Addresses:     []adminAddressStatus{},
+		Clients:       []adminClientStatus{},
+		Errors:        []adminErrorStatus{},
+	}
+	for _, a := range res.Addresses {
+		status.Addresses = append(status.Addresses, adminAddressStatus{
+			Address:     common.BytesToAddress(a.Address.GetAddress()),
+			Label:       a.Address.GetLabel(),
+			Active:      a.Address.GetActive(),
+			AddedBlock:  a.Address.GetAddedBlock(),
+			LogCount:    a.LogCount,
+			Backfilling: a.Backfilling,
+		})
+	}
+	for _, c := range res.Clients {
+		status.Clients = append(status.Clients, adminClientStatus{
+			ID:            c.Id,
+			Addresses:     addressesFromBytes(c.Addresses),
+			QueueLength:   c.QueueLength,
+			QueueCapacity: c.QueueCapacity,
+			Dropped:       c.Dropped,
+			Decode:        c.Decode,
+			ConnectedAt:   timeFromProtobuf(c.ConnectedAt),
+		})
+	}
+	for _, e := range res.Errors {
+		status.Errors = append(status.Errors, adminErrorStatus{Time: timeFromProtobuf(e.Time), Operation: e.Operation, Message: e.Message})
+	}
+	for _, e := range res.Endpoints {
+		endpoint := adminEndpointStatus{URI: e.Uri, Active: e.Active, Healthy: e.Healthy, Failures: e.Failures, LastError: e.LastError}
+		if e.LastErrorAt != nil {
+			at := timeFromProtobuf(e.LastErrorAt)
+			endpoint.LastErrorAt = &at
+		}
+		status.Endpoints = append(status.Endpoints, endpoint)
+	}
+	for _, w := range res.Webhooks {
+		status.Webhooks = append(status.Webhooks, adminWebhookStatus{
+			Name:        w.Name,
+			URL:         w.Url,
+			BlockNumber: w.Cursor.GetBlockNumber(),
+			Index:       w.Cursor.GetIndex(),
+		})
+	}
+	return status
+}
+
+func timeFromProtobuf(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.AsTime().Local()
+}
+
+const adminTimeFormat = "2006-01-02 15:04:05"
+
+func (s adminStatus) print(out io.Writer) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	running := "stopped"
+	if s.Running {
+		running = "running"
+	}
+	fmt.Fprintf(w, "chain-id:\t%d (%s)\n", s.ChainID, running)
+	fmt.Fprintf(w, "head block:\t%d\n", s.HeadBlock)
+	fmt.Fprintf(w, "scan block:\t%d\n", s.ScanBlock)
+	fmt.Fprintf(w, "lag:\t%d (confirmations: %d)\n", s.Lag, s.Confirmations)
+
+	fmt.Fprintf(w, "\naddresses: %d\n", len(s.Addresses))
+	for _, a := range s.Addresses {
+		var state []string
+		if !a.Active {
+			state = append(state, "removed")
+		}
+		if a.Backfilling {
+			state = append(state, "backfilling")
+		}
+		fmt.Fprintf(w, "  %s\t%s\tadded: %d\tlogs: %d\t%s\n", a.Address.Hex(), a.Label, a.AddedBlock, a.LogCount, strings.Join(state, ","))
+	}
+
+	fmt.Fprintf(w, "\nclients: %d\n", len(s.Clients))
+	for _, c := range s.Clients {
+		addresses := make([]string, len(c.Addresses))
+		for i, address := range c.Addresses {
+			addresses[i] = address.Hex()
+		}
+		fmt.Fprintf(w, "  #%d\tqueue: %d/%d\tdropped: %d\tconnected: %s\t%s\n",
+			c.ID, c.QueueLength, c.QueueCapacity, c.Dropped, c.ConnectedAt.Format(adminTimeFormat), strings.Join(addresses, ","))
+	}
+
+	if len(s.Endpoints) != 0 {
+		fmt.Fprintf(w, "\nendpoints: %d\n", len(s.Endpoints))
+		for _, e := range s.Endpoints {
+			active, health := " ", "healthy"
+			if e.Active {
+				active = "*"
+			}
+			if !e.Healthy {
+				health = fmt.Sprintf("failures: %d", e.Failures)
+			}
+			fmt.Fprintf(w, "  %s %s\t%s\t%s\n", active, e.URI, health, e.LastError)
+		}
+	}
+
+	if len(s.Webhooks) != 0 {
+		fmt.Fprintf(w, "\nwebhooks: %d\n", len(s.Webhooks))
+		for _, h := range s.Webhooks {
+			fmt.Fprintf(w, "  %s\t%s\tdelivered: %d:%d\n", h.Name, h.URL, h.BlockNumber, h.Index)
+		}
+	}
+
+	fmt.Fprintf(w, "\nrecent errors: %d\n", len(s.Errors))
+	for _, e := range s.Errors {
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", e.Time.Format(adminTimeFormat), e.Operation, e.Message)
+	}
+}
@@ -0,0 +1,181 @@
+package eventlogger_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// adminStatusRes 는 모든 항목이 설정된 status 의 응답이다.
+func adminStatusRes(at time.Time) *logger.StatusResMessage {
+	return &logger.StatusResMessage{
+		ChainId: 1337, HeadBlock: 120, ScanBlock: 100, Lag: 20, Running: true, Confirmations: 12,
+		Addresses: []*logger.AddressStatus{
+			{Address: &logger.WatchedAddress{Address: erc20.Bytes(), Label: "erc20", AddedBlock: 1, Active: true}, LogCount: 42},
+			{Address: &logger.WatchedAddress{Address: erc1155.Bytes(), AddedBlock: 90}, Backfilling: true},
+		},
+		Clients: []*logger.ClientStatus{
+			{Id: 7, Addresses: [][]byte{erc20.Bytes(), erc1155.Bytes()}, QueueLength: 3, QueueCapacity: 256, Dropped: 5, Decode: true, ConnectedAt: timestamppb.New(at)},
+		},
+		Errors: []*logger.ErrorStatus{{Time: timestamppb.New(at), Operation: "FilterLogs", Message: "timeout"}},
+		Endpoints: []*logger.EndpointStatus{
+			{Uri: "ws://node-1:8546", Active: true, Healthy: true},
+			{Uri: "ws://node-2:8546", Failures: 3, LastError: "connection refused", LastErrorAt: timestamppb.New(at)},
+		},
+		Webhooks: []*logger.WebhookStatus{{Name: "hook", Url: "http://hook:8080", Cursor: &logger.Cursor{BlockNumber: 99, Index: 2}}},
+	}
+}
+
+func TestAdminStatus(t *testing.T) {
+	at := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	local := at.Local().Format("2006-01-02 15:04:05")
+
+	tests := []struct {
+		name string
+		res  *logger.StatusResMessage
+		text []string // 공백을 하나로 합친 출력의 줄
+		json string
+	}{
+		{
+			name: "Empty",
+			res:  &logger.StatusResMessage{ChainId: 1},
+			text: []string{
+				"chain-id: 1 (stopped)",
+				"head block: 0",
+				"scan block: 0",
+				"lag: 0 (confirmations: 0)",
+				"",
+				"addresses: 0",
+				"",
+				"clients: 0",
+				"",
+				"recent errors: 0",
+			},
+			// 빈 목록은 null 이 아닌 [] 로, 설정되지 않은 endpoints 와 webhooks 는 생략한다.
+			json: `{"chainId":1,"headBlock":0,"scanBlock":0,"lag":0,"running":false,"confirmations":0,"addresses":[],"clients":[],"errors":[]}`,
+		},
+		{
+			name: "Full",
+			res:  adminStatusRes(at),
+			text: []string{
+				"chain-id: 1337 (running)",
+				"head block: 120",
+				"scan block: 100",
+				"lag: 20 (confirmations: 12)",
+				"",
+				"addresses: 2",
+				erc20.Hex() + " erc20 added: 1 logs: 42",
+				erc1155.Hex() + " added: 90 logs: 0 removed,backfilling",
+				"",
+				"clients: 1",
+				"#7 queue: 3/256 dropped: 5 connected: " + local + " " + erc20.Hex() + "," + erc1155.Hex(),
+				"",
+				"endpoints: 2",
+				"* ws://node-1:8546 healthy",
+				"ws://node-2:8546 failures: 3 connection refused",
+				"",
+				"webhooks: 1",
+				"hook http://hook:8080 delivered: 99:2",
+				"",
+				"recent errors: 1",
+				local + " FilterLogs timeout",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lines []string
+			for _, line := range strings.Split(strings.TrimSuffix(eventlogger.PrintStatus(tt.res), "\n"), "\n") {
+				lines = append(lines, strings.Join(strings.Fields(line), " "))
+			}
+			require.Equal(t, tt.text, lines)
+
+			if tt.json != "" {
+				data, err := eventlogger.StatusJSON(tt.res)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.json, data)
+			}
+		})
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := eventlogger.StatusJSON(adminStatusRes(at))
+		require.NoError(t, err)
+		localAt := at.Local().Format(time.RFC3339Nano)
+		// common.Address 는 소문자 hex 로 출력된다.
+		lower := func(address common.Address) string { return strings.ToLower(address.Hex()) }
+		require.JSONEq(t, `{
+			"chainId": 1337, "headBlock": 120, "scanBlock": 100, "lag": 20, "running": true, "confirmations": 12,
+			"addresses": [
+				{"address": "`+lower(erc20)+`", "label": "erc20", "active": true, "addedBlock": 1, "logCount": 42},
+				{"address": "`+lower(erc1155)+`", "active": false, "addedBlock": 90, "logCount": 0, "backfilling": true}
+			],
+			"clients": [
+				{"id": 7, "addresses": ["`+lower(erc20)+`", "`+lower(erc1155)+`"], "queueLength": 3, "queueCapacity": 256, "dropped": 5, "decode": true, "connectedAt": "`+localAt+`"}
+			],
+			"errors": [{"time": "`+localAt+`", "operation": "FilterLogs", "message": "timeout"}],
+			"endpoints": [
+				{"uri": "ws://node-1:8546", "active": true, "healthy": true, "failures": 0},
+				{"uri": "ws://node-2:8546", "active": false, "healthy": false, "failures": 3, "lastError": "connection refused", "lastErrorAt": "`+localAt+`"}
+			],
+			"webhooks": [{"name": "hook", "url": "http://hook:8080", "blockNumber": 99, "index": 2}]
+		}`, data)
+	})
+}
+
+func TestAdminArgs(t *testing.T) {
+	t.Run("Address", func(t *testing.T) {
+		tests := []struct {
+			args     []string
+			expected common.Address
+			err      string
+		}{
+			{args: []string{erc20.Hex()}, expected: erc20},
+			{args: []string{strings.ToLower(erc20.Hex())}, expected: erc20},
+			{args: []string{strings.TrimPrefix(erc20.Hex(), "0x")}, expected: erc20},
+			{args: []string{erc20.Hex(), erc1155.Hex()}, expected: erc20}, // 첫번째 인자만 사용한다.
+			{args: nil, err: "address is not set"},
+			{args: []string{"0x1234"}, err: "invalid address: 0x1234"},
+			{args: []string{"erc20"}, err: "invalid address: erc20"},
+		}
+		for _, tt := range tests {
+			address, err := eventlogger.AddressArg(tt.args...)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err, "%v", tt.args)
+				continue
+			}
+			require.NoError(t, err, "%v", tt.args)
+			require.Equal(t, tt.expected, address, "%v", tt.args)
+		}
+	})
+	t.Run("BlockNumber", func(t *testing.T) {
+		tests := []struct {
+			arg      string
+			expected uint64
+			ok       bool
+		}{
+			{arg: "100", expected: 100, ok: true},
+			{arg: "0x64", expected: 100, ok: true},
+			{arg: "0X64", expected: 100, ok: true},
+			{arg: "0", expected: 0, ok: true},
+			{arg: "-1"},
+			{arg: "latest"},
+			{arg: "0x"},
+		}
+		for _, tt := range tests {
+			block, err := eventlogger.ParseUint(tt.arg)
+			if !tt.ok {
+				require.Error(t, err, tt.arg)
+				continue
+			}
+			require.NoError(t, err, tt.arg)
+			require.Equal(t, tt.expected, block, tt.arg)
+		}
+	})
+}
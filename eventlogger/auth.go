@@ -0,0 +1,101 @@
+package eventlogger
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const bearerPrefix = "bearer "
+
+// adminAuth 는 Admin 서비스의 요청을 인증한다.
+// token 과 mutualTLS 가 모두 설정되면 둘 중 하나만 통과해도 인증된 것으로 본다.
+type adminAuth struct {
+	token     string
+	mutualTLS bool
+}
+
+func (a adminAuth) enabled() bool {
+	return a.token != "" || a.mutualTLS
+}
+
+// interceptor 는 Admin 서비스의 요청만 인증하고, 공개된 Logger 서비스의 요청은 그대로 전달한다.
+func (a adminAuth) interceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if a.enabled() && strings.HasPrefix(info.FullMethod, "/"+logger.Admin_ServiceDesc.ServiceName+"/") {
+		if err := a.authenticate(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return handler(ctx, req)
+}
+
+func (a adminAuth) authenticate(ctx context.Context) error {
+	if a.mutualTLS {
+		if p, ok := peer.FromContext(ctx); ok {
+			if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(info.State.VerifiedChains) != 0 {
+				return nil
+			}
+		}
+	}
+	if a.token != "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, value := range md.Get("authorization") {
+			if len(value) > len(bearerPrefix) && strings.EqualFold(value[:len(bearerPrefix)], bearerPrefix) &&
+				subtle.ConstantTimeCompare([]byte(value[len(bearerPrefix):]), []byte(a.token)) == 1 {
+				return nil
+			}
+		}
+	}
+	return status.Error(codes.Unauthenticated, "admin authentication required")
+}
+
+// bearerToken 은 요청마다 "authorization: Bearer <token>" 을 보내는 클라이언트 인증 정보이다.
+type bearerToken struct {
+	token  string
+	secure bool // TLS 로 연결하는지 여부, 평문으로 제공되는 서버도 admin-token 을 사용할 수 있다.
+}
+
+func (b bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + b.token}, nil
+}
+
+func (b bearerToken) RequireTransportSecurity() bool {
+	return b.secure
+}
+
+// NewTLSConfig 는 서버 인증서로 TLS 설정을 만든다.
+// clientCA 가 설정되면 클라이언트 인증서를 검증하며, 인증서가 없는 클라이언트도 Logger 서비스는 사용할 수 있다.
+func NewTLSConfig(certFile, keyFile, clientCA string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientCA != "" {
+		pem, err := os.ReadFile(clientCA)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("invalid client ca: " + clientCA)
+		}
+		config.ClientCAs, config.ClientAuth = pool, tls.VerifyClientCertIfGiven
+	}
+	return config, nil
+}
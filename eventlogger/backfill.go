@@ -0,0 +1,223 @@
+package eventlogger
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultBackfillRange   = 2000
+	backfillReportInterval = 10 * time.Second
+)
+
+// 노드가 한번에 처리할 수 있는 결과 수나 블록 범위를 넘었을때 반환하는 에러 메시지 목록
+var tooManyResultsMessages = []string{
+	"query returned more than", // geth, erigon
+	"too many",                 // too many results, too many blocks
+	"limit exceeded",           // infura, alchemy
+	"response size",            // alchemy
+	"block range",              // exceed maximum block range, block range is too wide
+}
+
+func isTooManyResults(err error) bool {
+	var rpcErr interface{ ErrorCode() int }
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32005 {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	for _, m := range tooManyResultsMessages {
+		if strings.Contains(message, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// backfill 은 확정된 블록까지 블록 범위 단위로 로그를 수집한 뒤, 새로운 헤드 단위의 스캔으로 넘긴다.
+// 노드가 결과가 너무 많다는 에러를 반환하면 범위를 절반으로 줄이고, 성공하면 s.backfillRange 까지 두배씩 늘린다.
+// 수집중에도 newHead 를 계속 받아 목표 블록을 갱신한다.
+func (s *chainLogger) backfill(newHead <-chan *types.Header) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	head, err := s.client.BlockNumber(ctx)
+	if err != nil {
+		s.logger.WithField("message", err.Error()).Error("fail to get block number, skip backfill")
+		head = 0
+	} else {
+		s.observeHead(head)
+	}
+
+	// 종료된 동안 재조직이 발생했는지 체크포인트의 블록 해시로 확인한다.
+	checkpoint := s.scanBlock.Load()
+	if hash, ok := s.headers.hash(checkpoint); ok {
+		header, err := s.client.HeaderByNumber(ctx, new(big.Int).SetUint64(checkpoint))
+		if err != nil {
+			// 확인하지 못한 재조직은 헤드 단위의 스캔에서 부모 블록 해시로 감지된다.
+			s.fail(s.logger.WithField("block-number", checkpoint), "header by number", err)
+		} else if header.Hash() != hash {
+			s.logger.WithField("block-number", checkpoint).Warn("chain reorg detected while stopped")
+			// 되돌리기 전에 수집을 이어가면 이후의 블록 해시로 재조직을 감지할 수 없다.
+			for s.rollback(ctx) != nil {
+				if !s.waitRetry() {
+					return
+				}
+			}
+		}
+	}
+
+	size, startBlock := s.backfillRange, s.scanBlock.Load()
+	started, reported := time.Now(), time.Now()
+	for s.scanBlock.Load() < s.stopBlock.Load() {
+		select {
+		case h := <-newHead:
+			head = h.Number.Uint64()
+			s.observeHead(head)
+		default:
+		}
+		current := s.scanBlock.Load()
+		if head < s.confirmations || head-s.confirmations <= current {
+			break
+		}
+		target := head - s.confirmations
+		from, to := current+1, min(current+size, target)
+
+		filter := s.filterQueryUntil(to)
+		filter.FromBlock, filter.ToBlock, filter.BlockHash = new(big.Int).SetUint64(from), new(big.Int).SetUint64(to), nil
+		logentry := s.logger.WithFields(logrus.Fields{
+			"from":  from,
+			"to":    to,
+			"range": size,
+		})
+		logentry.Trace("backfill")
+
+		logs, err := s.filterLogs(ctx, filter)
+		if err != nil {
+			if size > 1 && isTooManyResults(err) {
+				size = max(size/2, 1)
+				logentry.WithField("message", err.Error()).Debug("shrink backfill range")
+				continue
+			}
+			s.fail(logentry, "filter logs", err)
+			if !s.waitRetry() {
+				return
+			}
+			continue
+		}
+		header, err := s.client.HeaderByNumber(ctx, filter.ToBlock)
+		if err != nil {
+			s.fail(logentry, "header by number", err)
+			if !s.waitRetry() {
+				return
+			}
+			continue
+		}
+		s.scanBlock.Store(to)
+		s.headers.push(to, header.Hash())
+		s.metrics.blocksScanned.Add(float64(to - from + 1))
+		if err := s.commit(ctx, logs); err != nil {
+			if !s.waitRetry() {
+				return
+			}
+			continue
+		}
+		size = min(size*2, s.backfillRange)
+
+		if time.Since(reported) >= backfillReportInterval || to == target {
+			reported = time.Now()
+			s.logger.WithFields(logrus.Fields{
+				"scan-block": to,
+				"target":     target,
+				"progress":   float64(to-startBlock) * 100 / float64(target-startBlock),
+				"elapsed":    time.Since(started).Round(time.Second),
+			}).Info("backfill progress")
+		}
+	}
+}
+
+const addressBackfillRetryDelay = 5 * time.Second
+
+// addressBackfill 은 Admin.Add 로 추가된 주소의 과거 로그 수집 상태이다.
+type addressBackfill struct {
+	from, to uint64
+	current  atomic.Uint64 // 수집이 완료된 마지막 블록
+	cancel   context.CancelFunc
+}
+
+// backfillAddress 는 라이브 스캔과 별도로 job.from ~ job.to 블록에서 address 의 로그를 수집한다.
+// 라이브 스캔과 범위가 겹칠 수 있기 때문에 이미 저장된 로그는 다시 저장하지 않는다. (LogStore.InsertLogs)
+func (s *chainLogger) backfillAddress(ctx context.Context, address common.Address, job *addressBackfill) {
+	defer func() {
+		s.qlock.Lock()
+		defer s.qlock.Unlock()
+		if s.backfills[address] == job {
+			delete(s.backfills, address)
+		}
+	}()
+	logentry := s.logger.WithFields(logrus.Fields{
+		"address": address,
+		"from":    job.from,
+		"to":      job.to,
+	})
+	logentry.Info("start address backfill")
+
+	size, from := s.backfillRange, job.from
+	started, reported := time.Now(), time.Now()
+	for from <= job.to {
+		if ctx.Err() != nil {
+			logentry.WithField("scan-block", job.current.Load()).Warn("address backfill canceled")
+			return
+		}
+		to := min(from+size-1, job.to)
+		logs, err := s.filterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{address},
+		})
+		if err == nil {
+			stored := s.enrich(ctx, logs)
+			if err = s.store.InsertLogs(ctx, stored); err == nil {
+				s.metrics.observeStored(stored)
+			}
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				continue
+			}
+			if size > 1 && isTooManyResults(err) {
+				size = max(size/2, 1)
+				continue
+			}
+			logentry.WithFields(logrus.Fields{
+				"from-block": from,
+				"to-block":   to,
+				"message":    err.Error(),
+			}).Error("fail to backfill address, retry")
+			select {
+			case <-ctx.Done():
+			case <-time.After(addressBackfillRetryDelay):
+			}
+			continue
+		}
+		job.current.Store(to)
+		from, size = to+1, min(size*2, s.backfillRange)
+
+		if time.Since(reported) >= backfillReportInterval || to == job.to {
+			reported = time.Now()
+			logentry.WithFields(logrus.Fields{
+				"scan-block": to,
+				"progress":   float64(to-job.from+1) * 100 / float64(job.to-job.from+1),
+				"elapsed":    time.Since(started).Round(time.Second),
+			}).Info("address backfill progress")
+		}
+	}
+}
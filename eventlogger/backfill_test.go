@@ -0,0 +1,82 @@
+package eventlogger_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logstore"
+	"github.com/ethereum/go-ethereum"
+	"github.com/stretchr/testify/require"
+)
+
+// tooManyResults 는 블록 범위가 limit 보다 크면 geth 와 같은 에러를 반환한다.
+func tooManyResults(limit uint64) func(q ethereum.FilterQuery) error {
+	return func(q ethereum.FilterQuery) error {
+		if q.BlockHash == nil && q.ToBlock.Uint64()-q.FromBlock.Uint64()+1 > limit {
+			return fmt.Errorf("query returned more than %d results", 10000)
+		}
+		return nil
+	}
+}
+
+// backfillRanges 는 백필 단계에서 요청된 블록 범위를 요청된 순서로 반환한다.
+func (c *fakeChain) backfillRanges() [][2]uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	ranges := [][2]uint64{}
+	for _, q := range c.queries {
+		if q.BlockHash == nil {
+			ranges = append(ranges, [2]uint64{q.FromBlock.Uint64(), q.ToBlock.Uint64()})
+		}
+	}
+	return ranges
+}
+
+func TestBackfillRange(t *testing.T) {
+	t.Run("ShrinkAndGrow", func(t *testing.T) {
+		chain, store := newFakeChain(20), logstore.NewMemoryStore()
+		chain.filterErr = tooManyResults(4)
+		stop := startFakeChainServer(t, "localhost:50631", chain, store, &eventlogger.Options{BackfillRange: 8})
+		defer stop()
+		waitCheckpoint(t, store, 20)
+
+		// 실패하면 절반으로 줄이고, 성공하면 backfill-range 까지 두배로 늘린다.
+		require.Equal(t, [][2]uint64{
+			{1, 8}, {1, 4},
+			{5, 12}, {5, 8},
+			{9, 16}, {9, 12},
+			{13, 20}, {13, 16},
+			{17, 20},
+		}, chain.backfillRanges())
+
+		logs, err := store.QueryLogs(context.Background(), logstore.Query{})
+		require.NoError(t, err)
+		require.Len(t, logs, 20)
+		for i, log := range logs {
+			require.Equal(t, uint64(i+1), log.BlockNumber)
+		}
+	})
+	t.Run("MinimumRange", func(t *testing.T) {
+		chain, store := newFakeChain(6), logstore.NewMemoryStore()
+		chain.filterErr = tooManyResults(1)
+		stop := startFakeChainServer(t, "localhost:50632", chain, store, &eventlogger.Options{BackfillRange: 4})
+		defer stop()
+		waitCheckpoint(t, store, 6)
+
+		// 한 블록보다 작게 줄이지 않는다.
+		require.Equal(t, [][2]uint64{
+			{1, 4}, {1, 2}, {1, 1},
+			{2, 3}, {2, 2},
+			{3, 4}, {3, 3},
+			{4, 5}, {4, 4},
+			{5, 6}, {5, 5},
+			{6, 6},
+		}, chain.backfillRanges())
+
+		logs, err := store.QueryLogs(context.Background(), logstore.Query{})
+		require.NoError(t, err)
+		require.Len(t, logs, 6)
+	})
+}
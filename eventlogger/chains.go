@@ -0,0 +1,131 @@
+package eventlogger
+
+import (
+	"context"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logabi"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// chain 은 chainID 의 체인을 반환한다. chainID 가 0 이면 기본 체인을 반환한다.
+func (s *LoggerServer) chain(chainID uint64) (*chainLogger, error) {
+	if chainID == 0 {
+		return s.chains[0], nil
+	}
+	if c, ok := s.chainOf[chainID]; ok {
+		return c, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "unknown chain id: %d", chainID)
+}
+
+// ///////////////////
+// Public Procedure //
+// ///////////////////
+
+func (s *LoggerServer) Info(context.Context, *emptypb.Empty) (*logger.InfoResMessage, error) {
+	s.logger.Debug("Info")
+
+	res := &logger.InfoResMessage{Chains: make([]*logger.ChainInfo, len(s.chains))}
+	for i, c := range s.chains {
+		res.Chains[i] = c.info()
+	}
+	// 체인을 선택하지 않는 이전 버전의 클라이언트를 위해 기본 체인의 정보를 함께 전달한다.
+	res.ChainId, res.Address = res.Chains[0].ChainId, res.Chains[0].Address
+	return res, nil
+}
+
+func (s *LoggerServer) Connect(req *logger.ConnectReqMessage, stream grpc.ServerStreamingServer[logger.Log]) error {
+	c, err := s.chain(req.GetChainId())
+	if err != nil {
+		return err
+	}
+	return c.Connect(req, stream)
+}
+
+func (s *LoggerServer) GetLogs(ctx context.Context, req *logger.GetLogsReqMessage) (*logger.GetLogsResMessage, error) {
+	c, err := s.chain(req.GetChainId())
+	if err != nil {
+		return nil, err
+	}
+	return c.GetLogs(ctx, req)
+}
+
+// //////////////////
+// Admin Procedure //
+// //////////////////
+
+func (s *LoggerServer) Add(ctx context.Context, req *logger.AddressReqMessage) (*logger.BlockNumberMessage, error) {
+	c, err := s.chain(req.GetChainId())
+	if err != nil {
+		return nil, err
+	}
+	return c.Add(ctx, req)
+}
+
+func (s *LoggerServer) Remove(ctx context.Context, req *logger.AddressReqMessage) (*logger.BlockNumberMessage, error) {
+	c, err := s.chain(req.GetChainId())
+	if err != nil {
+		return nil, err
+	}
+	return c.Remove(ctx, req)
+}
+
+func (s *LoggerServer) List(ctx context.Context, req *logger.ChainReqMessage) (*logger.ListResMessage, error) {
+	c, err := s.chain(req.GetChainId())
+	if err != nil {
+		return nil, err
+	}
+	return c.List(ctx, req)
+}
+
+func (s *LoggerServer) Start(ctx context.Context, req *logger.BlockNumberMessage) (*emptypb.Empty, error) {
+	c, err := s.chain(req.GetChainId())
+	if err != nil {
+		return nil, err
+	}
+	return c.Start(ctx, req)
+}
+
+func (s *LoggerServer) Stop(ctx context.Context, req *logger.ChainReqMessage) (*logger.BlockNumberMessage, error) {
+	c, err := s.chain(req.GetChainId())
+	if err != nil {
+		return nil, err
+	}
+	return c.Stop(ctx, req)
+}
+
+func (s *LoggerServer) Status(ctx context.Context, req *logger.ChainReqMessage) (*logger.StatusResMessage, error) {
+	c, err := s.chain(req.GetChainId())
+	if err != nil {
+		return nil, err
+	}
+	return c.Status(ctx, req)
+}
+
+// AddABI 는 모든 체인에서 사용하는 ABI 목록에 ABI 를 등록한다.
+func (s *LoggerServer) AddABI(ctx context.Context, req *logger.ABIReqMessage) (*logger.ABIResMessage, error) {
+	contractABI, err := logabi.ParseABI([]byte(req.Abi))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid abi: "+err.Error())
+	}
+	var address *common.Address
+	if len(req.Address) != 0 {
+		address = new(common.Address)
+		*address = common.BytesToAddress(req.Address)
+	}
+	events := s.registry.Register(address, contractABI)
+	if len(events) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "event is not found")
+	}
+	s.logger.WithFields(logrus.Fields{
+		"address": address,
+		"events":  events,
+	}).Info("AddABI")
+	return &logger.ABIResMessage{Events: events}, nil
+}
@@ -2,12 +2,21 @@ package eventlogger
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"math/big"
+	"net"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/bang9ming9/bm-cli-tool/cmd/flags"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logabi"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logstore"
+	"github.com/bang9ming9/bm-cli-tool/metrics"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -15,33 +24,159 @@ import (
 	"github.com/urfave/cli/v2"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
 )
 
+// ChainConfig 는 [[chain]] 항목이다. 처음으로 설정된 체인이 기본 체인이 된다.
+type ChainConfig struct {
+	ChainID       uint64           `toml:"chain-id"` // 설정되면 엔드포인트의 체인 ID 와 같은지 확인
+	URI           string           `toml:"uri"`
+	URIs          []string         `toml:"uris"`           // uri 가 실패하면 순서대로 사용할 예비 엔드포인트
+	Polling       bool             `toml:"polling"`        // http(s) 가 아니어도 새로운 헤드를 폴링으로 확인
+	PollInterval  string           `toml:"poll-interval"`  // 폴링 주기 (ex: "2s")
+	Confirmations uint64           `toml:"confirmations"`  // 확정으로 간주할 블록 깊이
+	BackfillRange uint64           `toml:"backfill-range"` // 백필 단계의 최대 블록 범위
+	ScanBlock     uint64           `toml:"scan-block"`
+	Addresses     []common.Address `toml:"addresses"`
+	Enrich        bool             `toml:"enrich"` // 로그에 블록 시간과 트랜잭션 정보를 더해서 저장
+
+	RetryAttempts   int    `toml:"retry-attempts"`    // 노드 호출 별 최대 시도 횟수
+	RetryBackoff    string `toml:"retry-backoff"`     // 첫 재시도 전의 대기 시간 (ex: "500ms")
+	RetryMaxBackoff string `toml:"retry-max-backoff"` // 최대 대기 시간 (ex: "30s")
+}
+
+// ChainConfigs 는 [[chain]] 목록이다. 이전 버전의 [chain] 테이블은 하나의 항목으로 읽는다.
+type ChainConfigs []ChainConfig
+
+func (chains *ChainConfigs) UnmarshalTOML(decode func(interface{}) error) error {
+	var raw interface{}
+	if err := decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw.(map[string]interface{}); ok {
+		var chain ChainConfig
+		if err := decode(&chain); err != nil {
+			return err
+		}
+		*chains = ChainConfigs{chain}
+		return nil
+	}
+	return decode((*[]ChainConfig)(chains))
+}
+
+// LegacyFilterQuery 는 이전 버전의 [filter-query] 이다. 하나의 [chain] 과 함께 사용된 경우에만 체인 설정으로 옮긴다.
+type LegacyFilterQuery struct {
+	ScanBlock uint64           `toml:"scan-block"`
+	Addresses []common.Address `toml:"addresses"`
+}
+
+var errLegacyFilterQuery = errors.New("[filter-query] is only supported with a single [chain], move scan-block and addresses into each [[chain]]")
+
+// ABIConfig 는 [[abi]] 항목이다. 시작할때 이벤트 디코딩에 사용할 ABI 를 등록한다.
+type ABIConfig struct {
+	File    string         `toml:"file"`    // ABI JSON 배열, 또는 "abi" 필드를 가진 컴파일 결과(hardhat artifact)
+	Address common.Address `toml:"address"` // 설정되면 해당 주소의 로그에만 사용, 설정되지 않으면 이벤트 시그니처로 사용
+}
+
+// WebhookConfig 는 [[webhook]] 항목이다. 조건에 맞는 로그를 url 로 POST 한다.
+type WebhookConfig struct {
+	Name      string           `toml:"name"`     // 전달 위치를 저장하는 이름 (필수, 체인 별로 유일)
+	ChainID   uint64           `toml:"chain-id"` // 설정되지 않으면 기본 체인
+	URL       string           `toml:"url"`
+	Secret    string           `toml:"secret"`     // 설정되면 "X-Webhook-Signature: sha256=<hex(HMAC-SHA256(secret, body))>"
+	Addresses []common.Address `toml:"addresses"`  // 설정되지 않으면 모든 주소
+	Topics    [][]common.Hash  `toml:"topics"`     // 위치 별 OR 목록 (ex: [["0xddf2...b3ef"]])
+	FromBlock uint64           `toml:"from-block"` // 처음 시작할때 전달을 시작하는 블록 (기본값: 마지막 체크포인트 이후)
+	Decode    bool             `toml:"decode"`     // 등록된 ABI 로 디코딩한 이벤트를 함께 전달
+
+	BatchSize       int    `toml:"batch-size"`        // 한번에 전달하는 최대 로그 수 (기본값: 100)
+	BatchWait       string `toml:"batch-wait"`        // 배치가 가득 차지 않았을 때 더 기다리는 시간 (ex: "1s")
+	Timeout         string `toml:"timeout"`           // 요청 별 제한 시간 (기본값: "10s")
+	RetryBackoff    string `toml:"retry-backoff"`     // 첫 재시도 전의 대기 시간 (기본값: "1s")
+	RetryMaxBackoff string `toml:"retry-max-backoff"` // 최대 대기 시간 (기본값: "1m")
+}
+
 type Config struct {
-	Chain struct {
-		URI string `toml:"uri"`
-	} `toml:"chain"`
+	Chains   ChainConfigs    `toml:"chain"`
+	ABIs     []ABIConfig     `toml:"abi"`
+	Webhooks []WebhookConfig `toml:"webhook"`
 	Database struct {
-		URI        string `toml:"uri"`
+		Type       string `toml:"type"` // mongo(기본값), postgres, sqlite, memory (모든 체인이 같은 저장소를 사용하며, 로그는 체인 ID 로 구분된다)
+		URI        string `toml:"uri"`  // mongo: 연결 URI, postgres: DSN, sqlite: 파일 경로
 		Database   string `toml:"database"`
 		Collection string `toml:"collection"`
 	} `toml:"db"`
 	Server struct {
-		Host string `toml:"host"`
+		Host           string `toml:"host"`
+		AdminHost      string `toml:"admin-host"`      // 설정되면 Admin 서비스를 별도의 주소에서 제공
+		TLSCert        string `toml:"tls-cert"`        // 서버 인증서 파일
+		TLSKey         string `toml:"tls-key"`         // 서버 개인키 파일
+		TLSClientCA    string `toml:"tls-client-ca"`   // 설정되면 Admin 서비스는 이 CA 로 검증된 클라이언트 인증서를 허용 (mTLS)
+		AdminToken     string `toml:"admin-token"`     // 설정되면 Admin 서비스는 "authorization: Bearer <token>" 을 허용
+		SendQueueSize  int    `toml:"send-queue-size"` // 클라이언트 별 전송 큐의 크기
+		OverflowPolicy string `toml:"overflow-policy"` // 전송 큐가 가득 찼을 때: disconnect, drop
+		HTTPHost       string `toml:"http-host"`       // 설정되면 Logger 서비스를 HTTP(JSON, Server-Sent Events) 로도 제공
+		HTTPOrigin     string `toml:"http-origin"`     // HTTP 응답의 Access-Control-Allow-Origin (ex: "*")
 	} `toml:"server"`
+	Client struct {
+		URI      string `toml:"uri"`       // tail, admin info 가 연결하는 Logger 서비스 주소 (기본값: [server] 의 host)
+		AdminURI string `toml:"admin-uri"` // admin 이 연결하는 Admin 서비스 주소 (기본값: uri, 또는 [server] 의 admin-host, host)
+		CA       string `toml:"ca"`        // 설정되면 TLS 로 연결하며, 서버 인증서를 CA 인증서로 검증한다.
+		Cert     string `toml:"cert"`      // Admin 서비스 mTLS 의 클라이언트 인증서 파일
+		Key      string `toml:"key"`       // 클라이언트 개인키 파일
+		Token    string `toml:"token"`     // Admin 서비스 bearer token (기본값: [server] 의 admin-token)
+	} `toml:"client"`
+	Metrics struct {
+		Host string `toml:"host"` // 설정되면 GET /metrics 로 Prometheus 지표를 제공
+	} `toml:"metrics"`
 	Logger struct {
 		Level string `toml:"level"` // panic,fatal,error,warn,info,debug,trace
 		File  string `toml:"file"`
 	} `toml:"log"`
-	FilterQuery struct {
-		ScanBlock uint64           `toml:"scan-block"`
-		Addresses []common.Address `toml:"addresses"`
-	} `toml:"filter-query"`
+	// Deprecated: [[chain]] 의 scan-block, addresses 를 사용한다.
+	FilterQuery *LegacyFilterQuery `toml:"filter-query"`
+}
+
+// UnmarshalTOML 은 설정을 읽은 뒤 이전 버전의 [filter-query] 를 체인 설정으로 옮긴다.
+func (config *Config) UnmarshalTOML(decode func(interface{}) error) error {
+	type plain Config
+	if err := decode((*plain)(config)); err != nil {
+		return err
+	}
+	return config.migrateFilterQuery()
+}
+
+// migrateFilterQuery 는 [filter-query] 의 scan-block, addresses 를 하나뿐인 체인 설정에 더한다.
+// 체인이 여러개이거나, 체인 설정에도 같은 값이 설정되어 있으면 어느 쪽을 사용할지 알 수 없기 때문에 에러를 반환한다.
+func (config *Config) migrateFilterQuery() error {
+	legacy := config.FilterQuery
+	if legacy == nil {
+		return nil
+	}
+	if len(config.Chains) != 1 {
+		return errLegacyFilterQuery
+	}
+	chain := &config.Chains[0]
+	if (chain.ScanBlock != 0 && legacy.ScanBlock != 0) || (len(chain.Addresses) != 0 && len(legacy.Addresses) != 0) {
+		return errLegacyFilterQuery
+	}
+	if legacy.ScanBlock != 0 {
+		chain.ScanBlock = legacy.ScanBlock
+	}
+	if len(legacy.Addresses) != 0 {
+		chain.Addresses = legacy.Addresses
+	}
+	return nil
 }
 
 var Command = &cli.Command{
-	Name:  "event-logger",
-	Flags: []cli.Flag{flags.ConfigFlag},
+	Name:        "event-logger",
+	Flags:       []cli.Flag{flags.ConfigFlag},
+	Subcommands: []*cli.Command{exportCommand, importCommand, adminCommand, tailCommand},
 	Action: func(ctx *cli.Context) error {
 		config, err := flags.ReadConfig[Config](ctx)
 		if err != nil {
@@ -52,31 +187,63 @@ var Command = &cli.Command{
 			return err
 		}
 
-		logger.Info("Set Filter Query from Config...")
-		query, err := config.NewFilterQuery()
-		if err != nil {
-			return err
-		}
-		logger.Info("Dial ETH Client...")
-		client, err := ethclient.DialContext(ctx.Context, config.Chain.URI)
-		if err != nil {
-			return err
+		if config.FilterQuery != nil {
+			logger.Warn("[filter-query] is deprecated, move scan-block and addresses into [[chain]]")
 		}
-		defer client.Close()
 
-		logger.Info("Connect Mongodb...")
-		collection, err := config.ConnectDatabase()
-		if err != nil {
-			return err
+		chains := make([]Chain, len(config.Chains))
+		for i, cfg := range config.Chains {
+			logger.WithField("uri", cfg.URI).Info("Dial ETH Client...")
+			client, err := cfg.Dial(logger)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			chainID, err := client.ChainID(ctx.Context)
+			if err != nil {
+				return err
+			}
+
+			logger.WithField("chain-id", chainID).Info("Open Log Store...")
+			store, err := config.OpenStore(chainID.Uint64())
+			if err != nil {
+				return err
+			}
+			defer store.Close(ctx.Context)
+
+			chains[i] = Chain{
+				ID:            cfg.ChainID,
+				Client:        client,
+				Store:         store,
+				Query:         cfg.NewFilterQuery(),
+				Confirmations: cfg.Confirmations,
+				BackfillRange: cfg.BackfillRange,
+				Enrich:        cfg.Enrich,
+			}
 		}
-		defer collection.Database().Client().Disconnect(ctx.Context)
 
 		stopCh := make(chan os.Signal, 1)
 		signal.Notify(stopCh, syscall.SIGINT, syscall.SIGTERM)
 		defer close(stopCh)
 
+		options, err := config.NewOptions()
+		if err != nil {
+			return err
+		}
+		if options.Registry, err = config.NewRegistry(logger); err != nil {
+			return err
+		}
+
+		if config.Metrics.Host != "" {
+			server, err := metrics.Serve(config.Metrics.Host, logger.WithField("module", "Metrics"))
+			if err != nil {
+				return err
+			}
+			defer server.Close()
+		}
+
 		logger.Info("Open Query Server...")
-		return NewLoggerServer(stopCh, config.Server.Host, logger, client, collection, query)
+		return NewMultiChainLoggerServer(stopCh, config.Server.Host, logger, chains, options)
 	},
 }
 
@@ -123,10 +290,253 @@ func (config *Config) ConnectDatabase() (*mongo.Collection, error) {
 	return client.Database(cfg.Database).Collection(cfg.Collection), nil
 }
 
-func (config *Config) NewFilterQuery() (*ethereum.FilterQuery, error) {
-	cfg := config.FilterQuery
+// OpenStore 는 chainID 의 로그 저장소를 연다.
+func (config *Config) OpenStore(chainID uint64) (logstore.LogStore, error) {
+	cfg := config.Database
+	switch cfg.Type {
+	case "", "mongo":
+		collection, err := config.ConnectDatabase()
+		if err != nil {
+			return nil, err
+		}
+		return logstore.NewMongoStore(context.Background(), collection, chainID)
+	case "postgres":
+		db, err := gorm.Open(postgres.Open(cfg.URI), &gorm.Config{})
+		if err != nil {
+			return nil, err
+		}
+		return logstore.NewGormStore(db, chainID)
+	case "sqlite":
+		db, err := gorm.Open(sqlite.Open(cfg.URI), &gorm.Config{})
+		if err != nil {
+			return nil, err
+		}
+		return logstore.NewGormStore(db, chainID)
+	case "memory":
+		return logstore.NewMemoryStore(), nil
+	default:
+		return nil, errors.New("invalid db type: " + cfg.Type)
+	}
+}
+
+// Dial 은 uri, uris 의 엔드포인트를 순서대로 사용하는 FailoverBackend 를 반환한다.
+// 엔드포인트는 처음 사용될 때 연결된다.
+func (cfg *ChainConfig) Dial(log *logrus.Logger) (*FailoverBackend, error) {
+	var interval time.Duration
+	if cfg.PollInterval != "" {
+		var err error
+		if interval, err = time.ParseDuration(cfg.PollInterval); err != nil {
+			return nil, err
+		}
+	}
+	policy, err := cfg.NewRetryPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	uris := cfg.URIs
+	if cfg.URI != "" {
+		uris = append([]string{cfg.URI}, cfg.URIs...)
+	}
+	endpoints := make([]Endpoint, len(uris))
+	for i, uri := range uris {
+		endpoints[i] = Endpoint{URI: uri, Dial: func(ctx context.Context) (Backend, func(), error) {
+			return dialEndpoint(ctx, uri, cfg.Polling, interval)
+		}}
+	}
+	return NewFailoverBackend(logrus.NewEntry(log), endpoints, policy)
+}
+
+// http(s) 엔드포인트 이거나 polling 이 설정되어 있으면 새로운 헤드를 폴링으로 확인한다.
+func dialEndpoint(ctx context.Context, uri string, polling bool, interval time.Duration) (Backend, func(), error) {
+	client, err := ethclient.DialContext(ctx, uri)
+	if err != nil {
+		return nil, nil, err
+	}
+	if u, err := url.Parse(uri); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		polling = true
+	}
+	if !polling {
+		return client, client.Close, nil
+	}
+	return NewPollingBackend(client, interval), client.Close, nil
+}
+
+func (cfg *ChainConfig) NewRetryPolicy() (RetryPolicy, error) {
+	policy := RetryPolicy{MaxAttempts: cfg.RetryAttempts}
+	for _, d := range []struct {
+		value  string
+		target *time.Duration
+	}{{cfg.RetryBackoff, &policy.Backoff}, {cfg.RetryMaxBackoff, &policy.MaxBackoff}} {
+		if d.value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(d.value)
+		if err != nil {
+			return RetryPolicy{}, err
+		}
+		*d.target = duration
+	}
+	return policy, nil
+}
+
+func (cfg *ChainConfig) NewFilterQuery() *ethereum.FilterQuery {
 	return &ethereum.FilterQuery{
 		Addresses: cfg.Addresses,
 		FromBlock: new(big.Int).SetUint64(cfg.ScanBlock),
-	}, nil
+	}
+}
+
+func (config *Config) NewOptions() (*Options, error) {
+	cfg := config.Server
+	options := &Options{
+		SendQueueSize:  cfg.SendQueueSize,
+		OverflowPolicy: OverflowPolicy(cfg.OverflowPolicy),
+
+		AdminAddr:  cfg.AdminHost,
+		AdminToken: cfg.AdminToken,
+
+		HTTPAddr:        cfg.HTTPHost,
+		HTTPAllowOrigin: cfg.HTTPOrigin,
+	}
+	if cfg.TLSCert != "" || cfg.TLSKey != "" {
+		tlsConfig, err := NewTLSConfig(cfg.TLSCert, cfg.TLSKey, cfg.TLSClientCA)
+		if err != nil {
+			return nil, err
+		}
+		options.TLS, options.AdminMutualTLS = tlsConfig, cfg.TLSClientCA != ""
+	} else if cfg.TLSClientCA != "" {
+		return nil, errors.New("tls-client-ca requires tls-cert and tls-key")
+	}
+	for _, webhook := range config.Webhooks {
+		w, err := webhook.NewWebhook()
+		if err != nil {
+			return nil, err
+		}
+		options.Webhooks = append(options.Webhooks, w)
+	}
+	return options, nil
+}
+
+func (cfg *WebhookConfig) NewWebhook() (Webhook, error) {
+	webhook := Webhook{
+		Name:      cfg.Name,
+		ChainID:   cfg.ChainID,
+		URL:       cfg.URL,
+		Secret:    cfg.Secret,
+		Addresses: cfg.Addresses,
+		Topics:    cfg.Topics,
+		FromBlock: cfg.FromBlock,
+		Decode:    cfg.Decode,
+		BatchSize: cfg.BatchSize,
+	}
+	for _, d := range []struct {
+		value  string
+		target *time.Duration
+	}{
+		{cfg.BatchWait, &webhook.BatchWait},
+		{cfg.Timeout, &webhook.Timeout},
+		{cfg.RetryBackoff, &webhook.Retry.Backoff},
+		{cfg.RetryMaxBackoff, &webhook.Retry.MaxBackoff},
+	} {
+		if d.value == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(d.value)
+		if err != nil {
+			return Webhook{}, err
+		}
+		*d.target = duration
+	}
+	return webhook, nil
+}
+
+// ClientURI 는 tail, admin info 가 Logger 서비스를 호출할때 연결하는 주소를 반환한다.
+// [client] uri 가 설정되지 않으면 같은 설정 파일로 실행된 서버의 host 를 사용한다.
+func (config *Config) ClientURI() string {
+	if config.Client.URI != "" {
+		return config.Client.URI
+	}
+	return localHost(config.Server.Host)
+}
+
+// AdminClientURI 는 admin 이 Admin 서비스를 호출할때 연결하는 주소를 반환한다.
+// [client] admin-uri, uri 가 모두 설정되지 않으면 같은 설정 파일로 실행된 서버의 admin-host 또는 host 를 사용한다.
+// admin-host 가 설정된 서버는 Admin 서비스만 admin-host 에서 제공하기 때문에, Logger 서비스는 ClientURI 로 연결해야 한다.
+func (config *Config) AdminClientURI() string {
+	if config.Client.AdminURI != "" {
+		return config.Client.AdminURI
+	}
+	if config.Client.URI != "" {
+		return config.Client.URI
+	}
+	if config.Server.AdminHost != "" {
+		return localHost(config.Server.AdminHost)
+	}
+	return localHost(config.Server.Host)
+}
+
+// localHost 는 모든 인터페이스에서 대기하는 주소(ex: "0.0.0.0:50501")를 localhost 로 바꾼다.
+func localHost(host string) string {
+	if h, port, err := net.SplitHostPort(host); err == nil && (h == "" || net.ParseIP(h).IsUnspecified()) {
+		return net.JoinHostPort("localhost", port)
+	}
+	return host
+}
+
+// ClientToken 은 Admin 서비스를 호출할때 사용하는 bearer token 을 반환한다.
+func (config *Config) ClientToken() string {
+	if config.Client.Token != "" {
+		return config.Client.Token
+	}
+	return config.Server.AdminToken
+}
+
+// NewClientCredentials 는 [client] 의 TLS 설정을 반환한다. ca, cert 가 모두 설정되지 않으면 TLS 를 사용하지 않는다.
+func (config *Config) NewClientCredentials() (credentials.TransportCredentials, error) {
+	cfg := config.Client
+	if cfg.CA == "" && cfg.Cert == "" && cfg.Key == "" {
+		return insecure.NewCredentials(), nil
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.CA != "" {
+		pem, err := os.ReadFile(cfg.CA)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("invalid ca: " + cfg.CA)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.Cert != "" || cfg.Key != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.Cert, cfg.Key)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// NewRegistry 는 [[abi]] 의 ABI 파일들을 등록한 ABI 목록을 반환한다.
+func (config *Config) NewRegistry(log *logrus.Logger) (*logabi.Registry, error) {
+	registry := logabi.NewRegistry()
+	for _, cfg := range config.ABIs {
+		var address *common.Address
+		if cfg.Address != (common.Address{}) {
+			address = &cfg.Address
+		}
+		events, err := registry.LoadFile(cfg.File, address)
+		if err != nil {
+			return nil, err
+		}
+		log.WithFields(logrus.Fields{
+			"file":    cfg.File,
+			"address": address,
+			"events":  events,
+		}).Info("Load ABI")
+	}
+	return registry, nil
 }
@@ -0,0 +1,142 @@
+package eventlogger_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logstore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/naoina/toml"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestClientURI(t *testing.T) {
+	tests := []struct {
+		name      string
+		host      string
+		adminHost string
+		uri       string
+		adminURI  string
+		logger    string
+		admin     string
+	}{
+		{name: "Host", host: "0.0.0.0:50501", logger: "localhost:50501", admin: "localhost:50501"},
+		{name: "SplitListener", host: "0.0.0.0:50501", adminHost: ":50502", logger: "localhost:50501", admin: "localhost:50502"},
+		{name: "SpecificHost", host: "10.0.0.1:50501", adminHost: "10.0.0.1:50502", logger: "10.0.0.1:50501", admin: "10.0.0.1:50502"},
+		{name: "ClientURI", host: "0.0.0.0:50501", adminHost: "0.0.0.0:50502", uri: "remote:50501", logger: "remote:50501", admin: "remote:50501"},
+		{name: "ClientAdminURI", uri: "remote:50501", adminURI: "remote:50502", logger: "remote:50501", admin: "remote:50502"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := new(eventlogger.Config)
+			config.Server.Host, config.Server.AdminHost = tt.host, tt.adminHost
+			config.Client.URI, config.Client.AdminURI = tt.uri, tt.adminURI
+			require.Equal(t, tt.logger, config.ClientURI())
+			require.Equal(t, tt.admin, config.AdminClientURI())
+		})
+	}
+}
+
+func TestSplitListener(t *testing.T) {
+	const host, adminHost = "localhost:50611", "localhost:50612"
+	log := logrus.New()
+	log.SetLevel(logrus.ErrorLevel)
+	stopCh := make(chan os.Signal, 1)
+	defer func() { stopCh <- os.Interrupt }()
+	go func() {
+		chains := []eventlogger.Chain{{Client: &fakeBackend{}, Store: logstore.NewMemoryStore()}}
+		require.NoError(t, eventlogger.NewMultiChainLoggerServer(stopCh, host, log, chains, &eventlogger.Options{AdminAddr: adminHost}))
+	}()
+
+	// Admin 서비스만 제공하는 admin-host 에서는 Logger 서비스를 호출할 수 없다.
+	conn, err := grpc.NewClient(adminHost, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Eventually(t, func() bool {
+		_, err := logger.NewAdminClient(conn).Status(context.Background(), &logger.ChainReqMessage{})
+		return err == nil
+	}, 5*time.Second, 100*time.Millisecond)
+	_, err = logger.NewLoggerClient(conn).Info(context.Background(), &emptypb.Empty{})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+
+	// 같은 설정 파일의 [server] 로 연결하는 admin 명령은 서비스 별로 맞는 주소에 연결한다.
+	file := filepath.Join(t.TempDir(), "logger.toml")
+	require.NoError(t, os.WriteFile(file, []byte("[server]\nhost = \""+host+"\"\nadmin-host = \""+adminHost+"\"\n"), 0644))
+	app := &cli.App{Commands: []*cli.Command{eventlogger.Command}}
+	for _, command := range []string{"info", "status"} {
+		require.NoError(t, app.Run([]string{"bct", "event-logger", "admin", command, "--config", file}), command)
+	}
+}
+
+func TestLegacyConfig(t *testing.T) {
+	erc20, erc1155 := common.HexToAddress("0xc65Ef3Dc8D75769b02928778774eaA288A429403"), common.HexToAddress("0xc56dbaBCEd1a57f77209076bB6d711871a934f1f")
+
+	t.Run("ChainAndFilterQuery", func(t *testing.T) {
+		config := new(eventlogger.Config)
+		require.NoError(t, toml.Unmarshal([]byte(`
+[chain]
+uri = "ws://localhost:8546"
+
+[filter-query]
+scan-block = 10
+addresses = ["`+erc20.Hex()+`", "`+erc1155.Hex()+`"]
+`), config))
+		require.Len(t, config.Chains, 1)
+		require.Equal(t, "ws://localhost:8546", config.Chains[0].URI)
+		require.Equal(t, uint64(10), config.Chains[0].ScanBlock)
+		require.Equal(t, []common.Address{erc20, erc1155}, config.Chains[0].Addresses)
+	})
+	t.Run("Chains", func(t *testing.T) {
+		config := new(eventlogger.Config)
+		require.NoError(t, toml.Unmarshal([]byte(`
+[[chain]]
+uri = "ws://localhost:8546"
+scan-block = 10
+addresses = ["`+erc20.Hex()+`"]
+
+[[chain]]
+uri = "ws://localhost:9546"
+`), config))
+		require.Len(t, config.Chains, 2)
+		require.Equal(t, []common.Address{erc20}, config.Chains[0].Addresses)
+		require.Equal(t, "ws://localhost:9546", config.Chains[1].URI)
+		require.Nil(t, config.FilterQuery)
+	})
+	t.Run("FilterQueryWithChains", func(t *testing.T) {
+		// 어느 체인의 설정인지 알 수 없다.
+		err := toml.Unmarshal([]byte(`
+[[chain]]
+uri = "ws://localhost:8546"
+
+[[chain]]
+uri = "ws://localhost:9546"
+
+[filter-query]
+addresses = ["`+erc20.Hex()+`"]
+`), new(eventlogger.Config))
+		require.ErrorContains(t, err, "[filter-query]")
+	})
+	t.Run("FilterQueryConflict", func(t *testing.T) {
+		err := toml.Unmarshal([]byte(`
+[chain]
+uri = "ws://localhost:8546"
+addresses = ["`+erc1155.Hex()+`"]
+
+[filter-query]
+addresses = ["`+erc20.Hex()+`"]
+`), new(eventlogger.Config))
+		require.ErrorContains(t, err, "[filter-query]")
+	})
+}
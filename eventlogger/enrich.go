@@ -0,0 +1,116 @@
+package eventlogger
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logtypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// 보강 정보를 보관하는 최대 블록 수
+const enrichCacheSize = 128
+
+// blockContext 는 로그 보강을 위해 조회한 블록의 정보이다.
+// 영수증은 로그가 있는 트랜잭션만 처음 필요할 때 조회한다.
+type blockContext struct {
+	timestamp uint64
+	txs       map[common.Hash]*types.Transaction
+
+	lock     sync.Mutex
+	receipts map[common.Hash]*types.Receipt
+}
+
+// enricher 는 로그에 블록 시간과 트랜잭션의 from, to, status, gasUsed 를 더한다.
+// 블록은 블록 해시 별로 한번만 조회하여 보관하기 때문에, 재조직된 블록의 정보와 섞이지 않는다.
+type enricher struct {
+	client Backend
+	signer types.Signer
+	blocks *lru.Cache[common.Hash, *blockContext]
+}
+
+func newEnricher(client Backend, chainID *big.Int) *enricher {
+	return &enricher{
+		client: client,
+		signer: types.LatestSignerForChainID(chainID),
+		blocks: lru.NewCache[common.Hash, *blockContext](enrichCacheSize),
+	}
+}
+
+// enrich 는 로그들을 순서대로 보강한다. 에러가 발생하면 멈추고, 보강되지 않은 로그는 Context 가 nil 이다.
+func (e *enricher) enrich(ctx context.Context, logs []types.Log) ([]logtypes.Log, error) {
+	list := logtypes.WithoutContext(logs)
+	for i := range list {
+		context, err := e.context(ctx, &logs[i])
+		if err != nil {
+			return list, err
+		}
+		list[i].Context = context
+	}
+	return list, nil
+}
+
+func (e *enricher) context(ctx context.Context, log *types.Log) (*logtypes.LogContext, error) {
+	block, err := e.block(ctx, log.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	tx, ok := block.txs[log.TxHash]
+	if !ok {
+		return nil, fmt.Errorf("transaction %s is not in block %s", log.TxHash.Hex(), log.BlockHash.Hex())
+	}
+	from, err := types.Sender(e.signer, tx)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := e.receipt(ctx, block, log.TxHash)
+	if err != nil {
+		return nil, err
+	}
+	return &logtypes.LogContext{
+		BlockTimestamp: block.timestamp,
+		From:           from,
+		To:             tx.To(),
+		Status:         receipt.Status,
+		GasUsed:        receipt.GasUsed,
+	}, nil
+}
+
+func (e *enricher) block(ctx context.Context, hash common.Hash) (*blockContext, error) {
+	if block, ok := e.blocks.Get(hash); ok {
+		return block, nil
+	}
+	raw, err := e.client.BlockByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	block := &blockContext{
+		timestamp: raw.Time(),
+		txs:       make(map[common.Hash]*types.Transaction, len(raw.Transactions())),
+		receipts:  make(map[common.Hash]*types.Receipt),
+	}
+	for _, tx := range raw.Transactions() {
+		block.txs[tx.Hash()] = tx
+	}
+	e.blocks.Add(hash, block)
+	return block, nil
+}
+
+func (e *enricher) receipt(ctx context.Context, block *blockContext, txHash common.Hash) (*types.Receipt, error) {
+	block.lock.Lock()
+	defer block.lock.Unlock()
+
+	if receipt, ok := block.receipts[txHash]; ok {
+		return receipt, nil
+	}
+	receipt, err := e.client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	block.receipts[txHash] = receipt
+	return receipt, nil
+}
@@ -0,0 +1,38 @@
+package eventlogger
+
+import (
+	"sync"
+	"time"
+)
+
+const maxRecentErrors = 20
+
+// ErrorRecord 는 로그 수집중에 발생하여 재시도를 기다리는 에러이다.
+type ErrorRecord struct {
+	Time      time.Time
+	Operation string
+	Message   string
+}
+
+// recentErrors 는 최근에 발생한 에러를 maxRecentErrors 개까지 보관한다.
+type recentErrors struct {
+	lock sync.Mutex
+	list []ErrorRecord
+}
+
+func (r *recentErrors) add(operation string, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.list) == maxRecentErrors {
+		r.list = r.list[1:]
+	}
+	r.list = append(r.list, ErrorRecord{Time: time.Now(), Operation: operation, Message: err.Error()})
+}
+
+// snapshot 은 보관중인 에러를 발생한 순서로 반환한다.
+func (r *recentErrors) snapshot() []ErrorRecord {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]ErrorRecord(nil), r.list...)
+}
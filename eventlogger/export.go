@@ -0,0 +1,170 @@
+package eventlogger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bang9ming9/bm-cli-tool/cmd/flags"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logstore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	chainIDFlag = &cli.Uint64Flag{
+		Name:  "chain-id",
+		Usage: "chain id of the stored logs (default: the first [[chain]])",
+	}
+	fromFlag = &cli.Uint64Flag{
+		Name:  "from",
+		Usage: "first block number",
+	}
+	toFlag = &cli.Uint64Flag{
+		Name:  "to",
+		Usage: "last block number (0: latest)",
+	}
+	addressFlag = &cli.StringSliceFlag{
+		Name:  "address",
+		Usage: "contract address, can be repeated (default: all addresses)",
+	}
+	formatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: "file format: jsonl, csv (import default: by file extension)",
+	}
+	outputFlag = &cli.PathFlag{
+		Name:      "output",
+		Aliases:   []string{"o"},
+		TakesFile: true,
+		Usage:     "output file (default: stdout)",
+	}
+)
+
+var exportCommand = &cli.Command{
+	Name:      "export",
+	Usage:     "Write stored logs to a file or stdout",
+	ArgsUsage: " ",
+	Flags:     []cli.Flag{flags.ConfigFlag, chainIDFlag, fromFlag, toFlag, addressFlag, formatFlag, outputFlag},
+	Action: func(ctx *cli.Context) error {
+		format := logstore.FormatJSONL
+		if ctx.IsSet(formatFlag.Name) {
+			var err error
+			if format, err = logstore.ParseFormat(ctx.String(formatFlag.Name)); err != nil {
+				return err
+			}
+		}
+		query := logstore.Query{FromBlock: ctx.Uint64(fromFlag.Name), ToBlock: ctx.Uint64(toFlag.Name)}
+		if query.ToBlock != 0 && query.ToBlock < query.FromBlock {
+			return fmt.Errorf("--to %d is less than --from %d", query.ToBlock, query.FromBlock)
+		}
+		for _, address := range ctx.StringSlice(addressFlag.Name) {
+			if !common.IsHexAddress(address) {
+				return fmt.Errorf("invalid address: %s", address)
+			}
+			query.Addresses = append(query.Addresses, common.HexToAddress(address))
+		}
+
+		store, chainID, err := openStore(ctx)
+		if err != nil {
+			return err
+		}
+		defer store.Close(ctx.Context)
+
+		w := io.Writer(os.Stdout)
+		if path := ctx.Path(outputFlag.Name); path != "" {
+			file, err := os.Create(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			w = file
+		}
+		count, err := logstore.Export(ctx.Context, store, chainID, query, format, w)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "exported %d logs (chain-id: %d)\n", count, chainID)
+		return nil
+	},
+}
+
+var importCommand = &cli.Command{
+	Name:      "import",
+	Usage:     "Store logs of an exported file, skipping logs that are already stored",
+	ArgsUsage: "<file> (\"-\": stdin)",
+	Flags:     []cli.Flag{flags.ConfigFlag, chainIDFlag, formatFlag},
+	Action: func(ctx *cli.Context) error {
+		path := ctx.Args().First()
+		if path == "" {
+			return fmt.Errorf("file is not set")
+		}
+		format := logstore.FormatJSONL
+		if ctx.IsSet(formatFlag.Name) {
+			var err error
+			if format, err = logstore.ParseFormat(ctx.String(formatFlag.Name)); err != nil {
+				return err
+			}
+		} else if strings.EqualFold(filepath.Ext(path), ".csv") {
+			format = logstore.FormatCSV
+		}
+
+		r := io.Reader(os.Stdin)
+		if path != "-" {
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			r = file
+		}
+
+		store, chainID, err := openStore(ctx)
+		if err != nil {
+			return err
+		}
+		defer store.Close(ctx.Context)
+
+		count, err := logstore.Import(ctx.Context, store, chainID, format, r)
+		if err != nil {
+			return fmt.Errorf("read %d logs: %w", count, err)
+		}
+		fmt.Fprintf(os.Stderr, "read %d logs, already stored logs are skipped (chain-id: %d)\n", count, chainID)
+		return nil
+	},
+}
+
+// openStore 는 --chain-id 의 저장소를 연다. 설정되지 않으면 첫번째 [[chain]] 의 chain-id 를 사용하고,
+// chain-id 도 설정되지 않았다면 엔드포인트에서 체인 ID 를 가져온다.
+func openStore(ctx *cli.Context) (logstore.LogStore, uint64, error) {
+	config, err := flags.ReadConfig[Config](ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	chainID := ctx.Uint64(chainIDFlag.Name)
+	if chainID == 0 {
+		if len(config.Chains) == 0 {
+			return nil, 0, fmt.Errorf("chain is not set, use --chain-id")
+		}
+		if chainID = config.Chains[0].ChainID; chainID == 0 {
+			// 표준 출력은 export 에 사용하기 때문에 표준 에러로 로그를 남긴다.
+			client, err := config.Chains[0].Dial(logrus.New())
+			if err != nil {
+				return nil, 0, err
+			}
+			defer client.Close()
+			id, err := client.ChainID(ctx.Context)
+			if err != nil {
+				return nil, 0, err
+			}
+			chainID = id.Uint64()
+		}
+	}
+	store, err := config.OpenStore(chainID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return store, chainID, nil
+}
@@ -0,0 +1,92 @@
+package eventlogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logabi"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logstore"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logtypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+)
+
+// TestChain 은 로그 수집 없이 클라이언트 전달만 사용하는 chainLogger 이다.
+type TestChain struct {
+	c *chainLogger
+}
+
+func NewTestChain(chainID uint64, addresses []common.Address, sendQueueSize int, overflowPolicy OverflowPolicy) *TestChain {
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+	c := &chainLogger{
+		logger:         logrus.NewEntry(log),
+		chainID:        chainID,
+		store:          logstore.NewMemoryStore(),
+		registry:       logabi.NewRegistry(),
+		addrSet:        make(map[common.Address]struct{}),
+		clients:        make(map[uint32]*streamClient),
+		sendQueueSize:  sendQueueSize,
+		overflowPolicy: overflowPolicy,
+		metrics:        newChainMetrics(chainID),
+	}
+	for _, address := range addresses {
+		c.addrSet[address] = struct{}{}
+	}
+	return &TestChain{c}
+}
+
+func (t *TestChain) Connect(req *logger.ConnectReqMessage, stream grpc.ServerStreamingServer[logger.Log]) error {
+	return t.c.Connect(req, stream)
+}
+
+func (t *TestChain) Broadcast(log logtypes.Log) {
+	t.c.broadcast(log)
+}
+
+// Clients 는 연결된 클라이언트 수를 반환한다.
+func (t *TestChain) Clients() int {
+	t.c.slock.Lock()
+	defer t.c.slock.Unlock()
+	return len(t.c.clients)
+}
+
+// PrintStatus 는 admin status 의 텍스트 출력을 반환한다.
+func PrintStatus(res *logger.StatusResMessage) string {
+	var out bytes.Buffer
+	statusFromProtobuf(res).print(&out)
+	return out.String()
+}
+
+// StatusJSON 은 admin status --json 의 출력을 반환한다.
+func StatusJSON(res *logger.StatusResMessage) (string, error) {
+	data, err := json.Marshal(statusFromProtobuf(res))
+	return string(data), err
+}
+
+// AddressArg 는 args 를 admin add, remove 의 인자로 읽는다.
+func AddressArg(args ...string) (common.Address, error) {
+	set := flag.NewFlagSet("admin", flag.ContinueOnError)
+	if err := set.Parse(args); err != nil {
+		return common.Address{}, err
+	}
+	return addressArg(cli.NewContext(nil, set, nil))
+}
+
+var ParseUint = parseUint
+
+// TailText 는 tail 이 message 를 registry 로 디코딩하여 출력하는 한 줄을 반환한다.
+func TailText(registry *logabi.Registry, message *logger.Log) string {
+	var out bytes.Buffer
+	tailText(&out, tailLog(registry, message))
+	return out.String()
+}
+
+var (
+	TailRegistry   = tailRegistry
+	ChainAddresses = chainAddresses
+)
@@ -0,0 +1,273 @@
+package eventlogger
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultRetryAttempts   = 5
+	defaultRetryBackoff    = 500 * time.Millisecond
+	defaultRetryMaxBackoff = 30 * time.Second
+	dialTimeout            = 10 * time.Second
+)
+
+// RetryPolicy 는 FailoverBackend 의 호출 별 재시도 설정이다. 0 이면 기본값을 사용한다.
+type RetryPolicy struct {
+	MaxAttempts int           // 엔드포인트를 바꿔가며 호출하는 최대 횟수 (기본값: 5)
+	Backoff     time.Duration // 첫 재시도 전의 대기 시간, 재시도마다 두배로 늘어난다. (기본값: 500ms)
+	MaxBackoff  time.Duration // 최대 대기 시간 (기본값: 30s)
+}
+
+// backoff 는 attempt 번째 재시도 전의 대기 시간을 반환한다.
+// 여러 호출이 동시에 재시도하지 않도록 [d/2, d] 범위의 임의의 값을 사용한다.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, p.MaxBackoff)
+	return d/2 + rand.N(d/2+1)
+}
+
+// Endpoint 는 FailoverBackend 가 사용하는 노드이다. Dial 은 처음 사용될 때 호출된다.
+type Endpoint struct {
+	URI  string
+	Dial func(ctx context.Context) (Backend, func(), error)
+}
+
+// EndpointStatus 는 엔드포인트의 상태이다.
+type EndpointStatus struct {
+	URI         string
+	Active      bool // 현재 사용중인 엔드포인트
+	Healthy     bool // 마지막 호출이 성공했다.
+	Failures    int  // 연속으로 실패한 횟수
+	LastError   string
+	LastErrorAt time.Time
+}
+
+type endpoint struct {
+	Endpoint
+	backend Backend
+	close   func()
+
+	failures    int
+	lastError   error
+	lastErrorAt time.Time
+}
+
+// FailoverBackend 는 여러 엔드포인트 중 하나를 사용하며, 호출이 실패하면 다음 엔드포인트로 바꿔서
+// 지수 백오프로 재시도한다. 새로운 헤드 구독이 끊어지면 사용 가능한 엔드포인트로 다시 구독한다.
+type FailoverBackend struct {
+	logger *logrus.Entry
+	policy RetryPolicy
+
+	lock      sync.Mutex
+	endpoints []*endpoint
+	current   int
+}
+
+func NewFailoverBackend(log *logrus.Entry, endpoints []Endpoint, policy RetryPolicy) (*FailoverBackend, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("endpoint is not set")
+	}
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = defaultRetryAttempts
+	}
+	if policy.Backoff <= 0 {
+		policy.Backoff = defaultRetryBackoff
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = defaultRetryMaxBackoff
+	}
+	b := &FailoverBackend{
+		logger:    log.WithField("module", "FailoverBackend"),
+		policy:    policy,
+		endpoints: make([]*endpoint, len(endpoints)),
+	}
+	for i, e := range endpoints {
+		b.endpoints[i] = &endpoint{Endpoint: e}
+	}
+	return b, nil
+}
+
+// Endpoints 는 엔드포인트들의 상태를 설정된 순서로 반환한다.
+func (b *FailoverBackend) Endpoints() []EndpointStatus {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	list := make([]EndpointStatus, len(b.endpoints))
+	for i, e := range b.endpoints {
+		list[i] = EndpointStatus{
+			URI:         e.URI,
+			Active:      i == b.current,
+			Healthy:     e.failures == 0,
+			Failures:    e.failures,
+			LastErrorAt: e.lastErrorAt,
+		}
+		if e.lastError != nil {
+			list[i].LastError = e.lastError.Error()
+		}
+	}
+	return list
+}
+
+func (b *FailoverBackend) Close() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, e := range b.endpoints {
+		if e.close != nil {
+			e.close()
+		}
+		e.backend, e.close = nil, nil
+	}
+}
+
+// active 는 현재 사용중인 엔드포인트를 반환한다. 아직 연결되지 않았다면 연결한다.
+func (b *FailoverBackend) active() (*endpoint, Backend, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	e := b.endpoints[b.current]
+	if e.backend == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+		defer cancel()
+		backend, close, err := e.Dial(ctx)
+		if err != nil {
+			return e, nil, err
+		}
+		e.backend, e.close = backend, close
+	}
+	return e, e.backend, nil
+}
+
+func (b *FailoverBackend) succeed(e *endpoint) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	e.failures = 0
+}
+
+// fail 은 엔드포인트의 실패를 기록하고, 사용중인 엔드포인트라면 다음 엔드포인트로 바꾼다.
+func (b *FailoverBackend) fail(e *endpoint, err error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	e.failures++
+	e.lastError, e.lastErrorAt = err, time.Now()
+	logentry := b.logger.WithFields(logrus.Fields{
+		"uri":      e.URI,
+		"failures": e.failures,
+		"message":  err.Error(),
+	})
+	if len(b.endpoints) > 1 && b.endpoints[b.current] == e {
+		b.current = (b.current + 1) % len(b.endpoints)
+		logentry.WithField("next", b.endpoints[b.current].URI).Warn("failover to next endpoint")
+	} else {
+		logentry.Warn("endpoint call failed")
+	}
+}
+
+// isRetryable 은 다른 엔드포인트로 다시 호출할 에러인지 확인한다.
+// 취소된 호출과 조회 범위를 줄여야 하는 에러는 호출자에게 바로 반환한다.
+func isRetryable(err error) bool {
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) && !isTooManyResults(err)
+}
+
+func retryCall[T any](ctx context.Context, b *FailoverBackend, fn func(Backend) (T, error)) (T, error) {
+	var (
+		result T
+		err    error
+	)
+	for attempt := 0; attempt < b.policy.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return result, ctx.Err()
+			case <-time.After(b.policy.backoff(attempt)):
+			}
+		}
+		e, backend, dialErr := b.active()
+		if dialErr != nil {
+			err = dialErr
+			b.fail(e, err)
+			continue
+		}
+		if result, err = fn(backend); err == nil {
+			b.succeed(e)
+			return result, nil
+		} else if !isRetryable(err) {
+			return result, err
+		}
+		b.fail(e, err)
+	}
+	return result, err
+}
+
+// SubscribeNewHead 는 구독이 끊어지거나 구독에 실패하면 백오프 후 사용 가능한 엔드포인트로 다시 구독한다.
+// 반환된 구독은 Unsubscribe 될 때까지 에러로 종료되지 않는다.
+func (b *FailoverBackend) SubscribeNewHead(_ context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	var subscribed *endpoint
+	return event.ResubscribeErr(b.policy.MaxBackoff, func(ctx context.Context, lastErr error) (event.Subscription, error) {
+		if lastErr != nil && subscribed != nil {
+			b.fail(subscribed, lastErr)
+		}
+		e, backend, err := b.active()
+		if err != nil {
+			b.fail(e, err)
+			return nil, err
+		}
+		sub, err := backend.SubscribeNewHead(ctx, ch)
+		if err != nil {
+			b.fail(e, err)
+			return nil, err
+		}
+		subscribed = e
+		return sub, nil
+	}), nil
+}
+
+func (b *FailoverBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	return retryCall(ctx, b, func(backend Backend) (uint64, error) {
+		return backend.BlockNumber(ctx)
+	})
+}
+
+func (b *FailoverBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return retryCall(ctx, b, func(backend Backend) (*types.Header, error) {
+		return backend.HeaderByNumber(ctx, number)
+	})
+}
+
+func (b *FailoverBackend) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return retryCall(ctx, b, func(backend Backend) ([]types.Log, error) {
+		return backend.FilterLogs(ctx, q)
+	})
+}
+
+func (b *FailoverBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	return retryCall(ctx, b, func(backend Backend) (*big.Int, error) {
+		return backend.ChainID(ctx)
+	})
+}
+
+func (b *FailoverBackend) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return retryCall(ctx, b, func(backend Backend) (*types.Block, error) {
+		return backend.BlockByHash(ctx, hash)
+	})
+}
+
+func (b *FailoverBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return retryCall(ctx, b, func(backend Backend) (*types.Receipt, error) {
+		return backend.TransactionReceipt(ctx, txHash)
+	})
+}
@@ -0,0 +1,116 @@
+package eventlogger_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBackend 는 호출 횟수를 세고 설정된 에러를 반환한다.
+type fakeBackend struct {
+	eventlogger.Backend
+	err   error
+	calls int
+	head  *types.Header
+}
+
+func (b *fakeBackend) BlockNumber(context.Context) (uint64, error) {
+	b.calls++
+	return 10, b.err
+}
+
+func (b *fakeBackend) ChainID(context.Context) (*big.Int, error) {
+	return big.NewInt(1337), nil
+}
+
+func (b *fakeBackend) FilterLogs(context.Context, ethereum.FilterQuery) ([]types.Log, error) {
+	b.calls++
+	return nil, b.err
+}
+
+func (b *fakeBackend) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	b.calls++
+	if b.err != nil {
+		return nil, b.err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		select {
+		case ch <- b.head:
+		case <-quit:
+		}
+		<-quit
+		return nil
+	}), nil
+}
+
+func newFailoverBackend(t *testing.T, backends ...*fakeBackend) *eventlogger.FailoverBackend {
+	endpoints := make([]eventlogger.Endpoint, len(backends))
+	for i, backend := range backends {
+		endpoints[i] = eventlogger.Endpoint{URI: string(rune('a' + i)), Dial: func(context.Context) (eventlogger.Backend, func(), error) {
+			return backend, func() {}, nil
+		}}
+	}
+	policy := eventlogger.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+	b, err := eventlogger.NewFailoverBackend(logrus.NewEntry(logrus.New()), endpoints, policy)
+	require.NoError(t, err)
+	return b
+}
+
+func TestFailoverBackend(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Failover", func(t *testing.T) {
+		down, up := &fakeBackend{err: errors.New("connection refused")}, &fakeBackend{}
+		b := newFailoverBackend(t, down, up)
+		number, err := b.BlockNumber(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), number)
+
+		status := b.Endpoints()
+		require.False(t, status[0].Healthy)
+		require.Equal(t, 1, status[0].Failures)
+		require.Equal(t, "connection refused", status[0].LastError)
+		require.True(t, status[1].Active)
+		require.True(t, status[1].Healthy)
+	})
+	t.Run("GiveUp", func(t *testing.T) {
+		down := &fakeBackend{err: errors.New("connection refused")}
+		b := newFailoverBackend(t, down)
+		_, err := b.BlockNumber(ctx)
+		require.Error(t, err)
+		require.Equal(t, 3, down.calls)
+	})
+	t.Run("TooManyResults", func(t *testing.T) {
+		// 조회 범위를 줄여야 하는 에러는 재시도하지 않고 호출자에게 반환한다.
+		limited := &fakeBackend{err: errors.New("query returned more than 10000 results")}
+		b := newFailoverBackend(t, limited, &fakeBackend{})
+		_, err := b.FilterLogs(ctx, ethereum.FilterQuery{})
+		require.Error(t, err)
+		require.Equal(t, 1, limited.calls)
+		require.True(t, b.Endpoints()[0].Active)
+	})
+	t.Run("Resubscribe", func(t *testing.T) {
+		head := &types.Header{Number: big.NewInt(1)}
+		down, up := &fakeBackend{err: errors.New("connection refused")}, &fakeBackend{head: head}
+		b := newFailoverBackend(t, down, up)
+		ch := make(chan *types.Header)
+		sub, err := b.SubscribeNewHead(ctx, ch)
+		require.NoError(t, err)
+		defer sub.Unsubscribe()
+		select {
+		case recv := <-ch:
+			require.Equal(t, head, recv)
+		case <-time.After(5 * time.Second):
+			t.Fatal("new head is not delivered")
+		}
+	})
+}
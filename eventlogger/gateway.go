@@ -0,0 +1,401 @@
+package eventlogger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logabi"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logtypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// HTTP 게이트웨이는 gRPC 를 사용하기 어려운 클라이언트를 위해 Logger 서비스를 JSON 으로 제공한다.
+// 해시, 주소, 데이터는 0x 로 시작하는 hex 문자열, 숫자는 10진수이다.
+//
+//	GET /v1/info                 Logger.Info
+//	GET /v1/logs?<query>         Logger.GetLogs (pageSize, pageToken, toBlock 을 추가로 사용한다)
+//	GET /v1/connect?<query>      Logger.Connect (Server-Sent Events)
+//
+// <query>: address, addresses (반복 가능, 쉼표로 구분 가능), topic0 ~ topic3 (OR 목록, 쉼표로 구분),
+// fromBlock, resumeAfter (이벤트 id 또는 pageToken), chainId, decode (true, false)
+//
+// Connect 의 각 로그는 "event: log" 로 전달되며, 이벤트 id 는 로그의 위치이다.
+// 다시 연결하면 Last-Event-ID 헤더의 위치 이후부터 전달되고, 스트림이 에러로 끝나면 "event: error" 를 전달한다.
+
+const (
+	sseHeaderDelay = time.Second
+	sseKeepAlive   = 15 * time.Second
+)
+
+var gatewayTopics = []string{"topic0", "topic1", "topic2", "topic3"}
+
+type gatewayLog struct {
+	logtypes.LogJSON
+	Removed bool          `json:"removed"`
+	Dropped uint64        `json:"dropped,omitempty"`
+	Event   *logabi.Event `json:"event,omitempty"`
+}
+
+type gatewayChainInfo struct {
+	ChainID uint64           `json:"chainId"`
+	Address []common.Address `json:"address"`
+}
+
+type gatewayInfo struct {
+	ChainID uint64             `json:"chainId"` // 기본 체인
+	Address []common.Address   `json:"address"` // 기본 체인의 주소 목록
+	Chains  []gatewayChainInfo `json:"chains"`
+}
+
+type gatewayLogs struct {
+	Logs          []gatewayLog `json:"logs"`
+	NextPageToken string       `json:"nextPageToken,omitempty"`
+}
+
+type gatewayError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// gateway 는 s 를 호출하는 HTTP 핸들러를 반환한다. allowOrigin 이 설정되면 CORS 응답 헤더를 더한다.
+func (s *LoggerServer) gateway(allowOrigin string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/info", s.httpInfo)
+	mux.HandleFunc("GET /v1/logs", s.httpLogs)
+	mux.HandleFunc("GET /v1/connect", s.httpConnect)
+	if allowOrigin == "" {
+		return mux
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", allowOrigin)
+		w.Header().Set("Access-Control-Allow-Headers", "Last-Event-ID")
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func (s *LoggerServer) httpInfo(w http.ResponseWriter, r *http.Request) {
+	res, err := s.Info(r.Context(), &emptypb.Empty{})
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	info := gatewayInfo{ChainID: res.ChainId, Address: addressesFromBytes(res.Address)}
+	for _, chain := range res.Chains {
+		info.Chains = append(info.Chains, gatewayChainInfo{ChainID: chain.ChainId, Address: addressesFromBytes(chain.Address)})
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+func (s *LoggerServer) httpLogs(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	req := &logger.GetLogsReqMessage{PageToken: values.Get("pageToken")}
+	var err error
+	if req.Addresses, err = queryAddresses(values); err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	if req.Topics, err = queryTopics(values); err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	var pageSize uint64
+	for _, v := range []struct {
+		key    string
+		target *uint64
+	}{{"fromBlock", &req.FromBlock}, {"toBlock", &req.ToBlock}, {"chainId", &req.ChainId}, {"pageSize", &pageSize}} {
+		if *v.target, err = queryUint(values, v.key); err != nil {
+			writeHTTPError(w, err)
+			return
+		}
+	}
+	req.PageSize = uint32(min(pageSize, maxPageSize))
+	if req.Decode, err = queryBool(values, "decode"); err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+
+	res, err := s.GetLogs(r.Context(), req)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	logs := gatewayLogs{Logs: make([]gatewayLog, len(res.Logs)), NextPageToken: res.NextPageToken}
+	for i, message := range res.Logs {
+		logs.Logs[i] = logToGateway(message)
+	}
+	writeJSON(w, http.StatusOK, logs)
+}
+
+func (s *LoggerServer) httpConnect(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeHTTPError(w, status.Error(codes.Unimplemented, "streaming is not supported"))
+		return
+	}
+	values := r.URL.Query()
+	req := new(logger.ConnectReqMessage)
+	var err error
+	if req.Addresses, err = queryAddresses(values); err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	if req.Topics, err = queryTopics(values); err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	if req.FromBlock, err = queryUint(values, "fromBlock"); err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	if req.ChainId, err = queryUint(values, "chainId"); err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	if req.Decode, err = queryBool(values, "decode"); err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	// EventSource 가 다시 연결할 때 보내는 Last-Event-ID 를 resumeAfter 보다 우선한다.
+	resumeAfter := values.Get("resumeAfter")
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		resumeAfter = id
+	}
+	if resumeAfter != "" {
+		cursor, err := logtypes.CursorFromToken(resumeAfter)
+		if err != nil {
+			writeHTTPError(w, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		req.ResumeAfter = logtypes.CursorToProtobuf(cursor)
+	}
+
+	stream := &sseStream{ctx: r.Context(), w: w, flusher: flusher}
+	done, exited := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(exited)
+		stream.keepAlive(done)
+	}()
+
+	err = s.Connect(req, stream)
+	// 핸들러가 반환된 뒤에는 ResponseWriter 를 쓸 수 없기 때문에 keepAlive 가 종료될 때까지 기다린다.
+	close(done)
+	<-exited
+	if err != nil {
+		stream.error(err)
+	}
+}
+
+// sseStream 은 Connect 의 로그를 Server-Sent Events 로 전달한다.
+// 요청의 확인이 끝날 수 있도록 응답 헤더는 처음으로 로그를 전달하거나 sseHeaderDelay 가 지난 뒤에 쓰며,
+// 그 전에 Connect 가 실패하면 에러 응답을 보낸다.
+type sseStream struct {
+	grpc.ServerStream // Send, Context 외에는 사용하지 않는다.
+	ctx               context.Context
+	w                 http.ResponseWriter
+	flusher           http.Flusher
+
+	lock    sync.Mutex
+	started bool
+}
+
+func (s *sseStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *sseStream) Send(message *logger.Log) error {
+	data, err := json.Marshal(logToGateway(message))
+	if err != nil {
+		return err
+	}
+	id := logtypes.CursorOf(logtypes.LogFromProtobuf(message)).Token()
+	return s.write(fmt.Sprintf("id: %s\nevent: log\ndata: %s\n\n", id, data))
+}
+
+func (s *sseStream) write(event string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.started {
+		s.started = true
+		header := s.w.Header()
+		header.Set("Content-Type", "text/event-stream")
+		header.Set("Cache-Control", "no-cache")
+		header.Set("X-Accel-Buffering", "no")
+		s.w.WriteHeader(http.StatusOK)
+	}
+	return s.flush(event)
+}
+
+func (s *sseStream) flush(event string) error {
+	if _, err := s.w.Write([]byte(event)); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// keepAlive 는 로그가 없는 동안 프록시가 연결을 끊지 않도록 주석을 보낸다.
+func (s *sseStream) keepAlive(done <-chan struct{}) {
+	timer := time.NewTimer(sseHeaderDelay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-s.ctx.Done():
+			return
+		case <-timer.C:
+			if s.write(": keep-alive\n\n") != nil {
+				return
+			}
+			timer.Reset(sseKeepAlive)
+		}
+	}
+}
+
+func (s *sseStream) error(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.started {
+		s.started = true
+		writeHTTPError(s.w, err)
+		return
+	}
+	data, _ := json.Marshal(errorToGateway(err))
+	s.flush(fmt.Sprintf("event: error\ndata: %s\n\n", data))
+}
+
+func logToGateway(message *logger.Log) gatewayLog {
+	log := logtypes.Log{Log: logtypes.LogFromProtobuf(message), Context: logtypes.ContextFromProtobuf(message.Context)}
+	res := gatewayLog{LogJSON: logtypes.LogToJSON(message.ChainId, log), Removed: message.Removed, Dropped: message.Dropped}
+	if message.Event != nil {
+		res.Event = logabi.EventFromProtobuf(message.Event)
+	}
+	return res
+}
+
+func addressesFromBytes(list [][]byte) []common.Address {
+	addresses := make([]common.Address, len(list))
+	for i, address := range list {
+		addresses[i] = common.BytesToAddress(address)
+	}
+	return addresses
+}
+
+func errorToGateway(err error) gatewayError {
+	s, _ := status.FromError(err)
+	return gatewayError{Code: s.Code().String(), Message: s.Message()}
+}
+
+func writeHTTPError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.ResourceExhausted:
+		code = http.StatusTooManyRequests
+	case codes.Unavailable:
+		code = http.StatusServiceUnavailable
+	case codes.Unimplemented:
+		code = http.StatusNotImplemented
+	}
+	writeJSON(w, code, errorToGateway(err))
+}
+
+func writeJSON(w http.ResponseWriter, code int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(value)
+}
+
+// 쿼리 파라미터
+
+func queryList(values url.Values, key string) []string {
+	var list []string
+	for _, value := range values[key] {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+func queryAddresses(values url.Values) ([][]byte, error) {
+	var addresses [][]byte
+	for _, key := range []string{"address", "addresses"} {
+		for _, address := range queryList(values, key) {
+			if !common.IsHexAddress(address) {
+				return nil, status.Error(codes.InvalidArgument, "invalid address: "+address)
+			}
+			addresses = append(addresses, common.HexToAddress(address).Bytes())
+		}
+	}
+	return addresses, nil
+}
+
+// queryTopics 는 topic0 ~ topic3 를 위치 별 OR 목록으로 변환한다. 설정되지 않은 위치는 모든 토픽과 일치한다.
+func queryTopics(values url.Values) ([]*logger.Topics, error) {
+	topics := make([][]common.Hash, 0, len(gatewayTopics))
+	for i, key := range gatewayTopics {
+		for _, topic := range queryList(values, key) {
+			hash, err := hexutil.Decode(topic)
+			if err != nil || len(hash) != common.HashLength {
+				return nil, status.Error(codes.InvalidArgument, "invalid "+key+": "+topic)
+			}
+			for len(topics) <= i {
+				topics = append(topics, nil)
+			}
+			topics[i] = append(topics[i], common.BytesToHash(hash))
+		}
+	}
+	return logtypes.TopicsToProtobuf(topics), nil
+}
+
+// queryUint 는 10진수 또는 0x 로 시작하는 16진수를 읽는다. 설정되지 않으면 0 이다.
+func queryUint(values url.Values, key string) (uint64, error) {
+	value := values.Get(key)
+	if value == "" {
+		return 0, nil
+	}
+	number, err := parseUint(value)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, "invalid "+key+": "+value)
+	}
+	return number, nil
+}
+
+// parseUint 는 10진수 또는 0x 로 시작하는 16진수를 읽는다.
+func parseUint(value string) (uint64, error) {
+	if strings.HasPrefix(value, "0x") || strings.HasPrefix(value, "0X") {
+		return hexutil.DecodeUint64(value)
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func queryBool(values url.Values, key string) (bool, error) {
+	value := values.Get(key)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, status.Error(codes.InvalidArgument, "invalid "+key+": "+value)
+	}
+	return b, nil
+}
@@ -0,0 +1,54 @@
+package eventlogger
+
+import "github.com/ethereum/go-ethereum/common"
+
+// 재조직을 감지하기 위해 추적하는 최대 블록 수
+const maxTrackedHeaders = 128
+
+type blockRef struct {
+	number uint64
+	hash   common.Hash
+}
+
+// headerChain 은 스캔이 완료된 블록들의 해시를 연속된 블록 번호 순서로 보관한다.
+type headerChain struct {
+	refs []blockRef
+}
+
+func (c *headerChain) push(number uint64, hash common.Hash) {
+	// 연속되지 않은 블록이 들어오면 이전 추적 정보는 더이상 유효하지 않다.
+	if length := len(c.refs); length != 0 && c.refs[length-1].number+1 != number {
+		c.refs = c.refs[:0]
+	}
+	c.refs = append(c.refs, blockRef{number, hash})
+	if len(c.refs) > maxTrackedHeaders {
+		c.refs = c.refs[len(c.refs)-maxTrackedHeaders:]
+	}
+}
+
+func (c *headerChain) hash(number uint64) (common.Hash, bool) {
+	if len(c.refs) == 0 {
+		return common.Hash{}, false
+	}
+	first := c.refs[0].number
+	if number < first || number-first >= uint64(len(c.refs)) {
+		return common.Hash{}, false
+	}
+	return c.refs[number-first].hash, true
+}
+
+// truncate 는 number 보다 큰 블록들을 제거하고, 제거된 블록들을 반환한다.
+func (c *headerChain) truncate(number uint64) []blockRef {
+	for i, ref := range c.refs {
+		if ref.number > number {
+			removed := append([]blockRef{}, c.refs[i:]...)
+			c.refs = c.refs[:i]
+			return removed
+		}
+	}
+	return nil
+}
+
+func (c *headerChain) reset() {
+	c.refs = c.refs[:0]
+}
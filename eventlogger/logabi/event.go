@@ -0,0 +1,136 @@
+package logabi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Event 는 ABI 로 디코딩된 로그이다.
+type Event struct {
+	Name      string `json:"name"`
+	Signature string `json:"signature"` // ex: Transfer(address,address,uint256)
+	Args      []Arg  `json:"args"`      // ABI 에 정의된 순서
+}
+
+// Arg 는 이벤트의 인자이다. Value 는 ValueToJSON 으로 인코딩된 값이다.
+type Arg struct {
+	Name    string          `json:"name"`
+	Type    string          `json:"type"`
+	Indexed bool            `json:"indexed"`
+	Value   json.RawMessage `json:"value"`
+}
+
+// DecodeEvent 는 event 로 로그를 디코딩한다.
+func DecodeEvent(event abi.Event, log types.Log) (*Event, error) {
+	if len(log.Topics) == 0 || log.Topics[0] != event.ID {
+		return nil, errors.New("event id mismatch")
+	}
+	topics := log.Topics[1:]
+	data, err := event.Inputs.Unpack(log.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := &Event{Name: event.RawName, Signature: event.Sig, Args: make([]Arg, len(event.Inputs))}
+	for i, input := range event.Inputs {
+		var value interface{}
+		if input.Indexed {
+			if len(topics) == 0 {
+				return nil, errors.New("topic/field count mismatch")
+			}
+			// 인자 하나씩 읽어서, 이름이 없거나 같은 인자도 위치로 구분한다.
+			values := make(map[string]interface{})
+			if err := abi.ParseTopicsIntoMap(values, abi.Arguments{input}, topics[:1]); err != nil {
+				return nil, err
+			}
+			value, topics = values[input.Name], topics[1:]
+		} else {
+			value, data = data[0], data[1:]
+		}
+		encoded, err := ValueToJSON(input, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", input.Name, err)
+		}
+		decoded.Args[i] = Arg{Name: input.Name, Type: input.Type.String(), Indexed: input.Indexed, Value: encoded}
+	}
+	if len(topics) != 0 {
+		return nil, errors.New("topic/field count mismatch")
+	}
+	return decoded, nil
+}
+
+// ValueToJSON 은 디코딩된 인자의 값을 JSON 으로 인코딩한다.
+// 정수는 10진수 문자열, address/bytes/hash 는 0x 로 시작하는 hex 문자열, 배열은 배열, 튜플은 이름을 키로 하는 객체이다.
+// indexed 인 동적 타입(string, bytes, 배열, 튜플)은 토픽에 값의 keccak256 해시만 있으므로 해시를 인코딩한다.
+func ValueToJSON(input abi.Argument, value interface{}) (json.RawMessage, error) {
+	if hash, ok := value.(common.Hash); ok && input.Indexed {
+		return json.Marshal(hash.Hex())
+	}
+	return json.Marshal(jsonValue(input.Type, reflect.ValueOf(value)))
+}
+
+func jsonValue(t abi.Type, v reflect.Value) interface{} {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		return fmt.Sprint(v.Interface()) // int8 ~ int64, *big.Int
+	case abi.BoolTy:
+		return v.Bool()
+	case abi.StringTy:
+		return v.String()
+	case abi.AddressTy:
+		return v.Interface().(common.Address).Hex()
+	case abi.BytesTy:
+		return hexutil.Encode(v.Bytes())
+	case abi.FixedBytesTy, abi.FunctionTy, abi.HashTy:
+		bytes := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(bytes), v)
+		return hexutil.Encode(bytes)
+	case abi.SliceTy, abi.ArrayTy:
+		list := make([]interface{}, v.Len())
+		for i := range list {
+			list[i] = jsonValue(*t.Elem, v.Index(i))
+		}
+		return list
+	case abi.TupleTy:
+		if v.Kind() == reflect.Pointer {
+			v = v.Elem()
+		}
+		object := make(map[string]interface{}, len(t.TupleElems))
+		for i, elem := range t.TupleElems {
+			object[t.TupleRawNames[i]] = jsonValue(*elem, v.Field(i))
+		}
+		return object
+	default:
+		return v.Interface()
+	}
+}
+
+func EventToProtobuf(e *Event) *logger.DecodedEvent {
+	if e == nil {
+		return nil
+	}
+	message := &logger.DecodedEvent{Name: e.Name, Signature: e.Signature, Args: make([]*logger.EventArg, len(e.Args))}
+	for i, arg := range e.Args {
+		message.Args[i] = &logger.EventArg{Name: arg.Name, Type: arg.Type, Indexed: arg.Indexed, Value: string(arg.Value)}
+	}
+	return message
+}
+
+func EventFromProtobuf(e *logger.DecodedEvent) *Event {
+	if e == nil {
+		return nil
+	}
+	event := &Event{Name: e.Name, Signature: e.Signature, Args: make([]Arg, len(e.Args))}
+	for i, arg := range e.Args {
+		event.Args[i] = Arg{Name: arg.Name, Type: arg.Type, Indexed: arg.Indexed, Value: json.RawMessage(arg.Value)}
+	}
+	return event
+}
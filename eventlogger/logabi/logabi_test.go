@@ -0,0 +1,120 @@
+package logabi_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logabi"
+	abis "github.com/bang9ming9/bm-governance/abis"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const erc721ABI = `[{"anonymous":false,"inputs":[
+	{"indexed":true,"name":"from","type":"address"},
+	{"indexed":true,"name":"to","type":"address"},
+	{"indexed":true,"name":"tokenId","type":"uint256"}
+],"name":"Transfer","type":"event"}]`
+
+const complexABI = `{"contractName":"Complex","abi":[{"anonymous":false,"inputs":[
+	{"indexed":true,"name":"key","type":"string"},
+	{"indexed":false,"name":"values","type":"uint8[]"},
+	{"indexed":false,"name":"pair","type":"tuple","components":[
+		{"name":"owner","type":"address"},
+		{"name":"id","type":"bytes4"}
+	]},
+	{"indexed":false,"name":"","type":"bool"}
+],"name":"Complex","type":"event"}]}`
+
+func makeLog(t *testing.T, event abi.Event, address common.Address, topics []common.Hash, values ...interface{}) types.Log {
+	data, err := event.Inputs.NonIndexed().Pack(values...)
+	require.NoError(t, err)
+	return types.Log{Address: address, Topics: append([]common.Hash{event.ID}, topics...), Data: data}
+}
+
+func requireArgs(t *testing.T, event *logabi.Event, expected map[string]string) {
+	require.Len(t, event.Args, len(expected))
+	for _, arg := range event.Args {
+		require.JSONEq(t, expected[arg.Name], string(arg.Value), arg.Name)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	erc20, err := logabi.ParseABI([]byte(abis.BmErc20MetaData.ABI))
+	require.NoError(t, err)
+	erc721, err := logabi.ParseABI([]byte(erc721ABI))
+	require.NoError(t, err)
+
+	token, from, to := common.HexToAddress("0x10"), common.HexToAddress("0xa"), common.HexToAddress("0xb")
+	transfer20 := makeLog(t, erc20.Events["Transfer"], token, []common.Hash{common.BytesToHash(from[:]), common.BytesToHash(to[:])}, big.NewInt(1000))
+	transfer721 := makeLog(t, erc721.Events["Transfer"], token, []common.Hash{common.BytesToHash(from[:]), common.BytesToHash(to[:]), common.BigToHash(big.NewInt(7))})
+
+	registry := logabi.NewRegistry()
+	_, err = registry.Decode(transfer20)
+	require.ErrorIs(t, err, logabi.ErrUnknownEvent)
+
+	t.Run("Signature", func(t *testing.T) {
+		signatures := registry.Register(nil, erc20)
+		require.Equal(t, []string{"Approval(address,address,uint256)", "Claimed(uint256,address,uint256)", "Transfer(address,address,uint256)"}, signatures)
+		registry.Register(nil, erc721)
+
+		// 같은 시그니처의 이벤트는 디코딩에 성공하는 이벤트를 사용한다.
+		event, err := registry.Decode(transfer20)
+		require.NoError(t, err)
+		require.Equal(t, "Transfer", event.Name)
+		require.Equal(t, "Transfer(address,address,uint256)", event.Signature)
+		requireArgs(t, event, map[string]string{"from": `"` + from.Hex() + `"`, "to": `"` + to.Hex() + `"`, "value": `"1000"`})
+		require.True(t, event.Args[0].Indexed)
+		require.False(t, event.Args[2].Indexed)
+		require.Equal(t, "uint256", event.Args[2].Type)
+
+		event, err = registry.Decode(transfer721)
+		require.NoError(t, err)
+		requireArgs(t, event, map[string]string{"from": `"` + from.Hex() + `"`, "to": `"` + to.Hex() + `"`, "tokenId": `"7"`})
+	})
+	t.Run("Address", func(t *testing.T) {
+		// 주소로 등록된 ABI 를 먼저 사용한다.
+		registry.Register(&token, erc721)
+		_, err := registry.Decode(transfer20)
+		var decodeErr *logabi.DecodeError
+		require.ErrorAs(t, err, &decodeErr)
+		require.Equal(t, "Transfer(address,address,uint256)", decodeErr.Signature)
+		transfer20.Address = common.HexToAddress("0x20")
+		_, err = registry.Decode(transfer20)
+		require.NoError(t, err)
+	})
+	t.Run("Types", func(t *testing.T) {
+		complex, err := logabi.ParseABI([]byte(complexABI))
+		require.NoError(t, err)
+		registry.Register(nil, complex)
+
+		pair := struct {
+			Owner common.Address
+			Id    [4]byte
+		}{from, [4]byte{1, 2, 3, 4}}
+		key := crypto.Keccak256Hash([]byte("key"))
+		event, err := registry.Decode(makeLog(t, complex.Events["Complex"], token, []common.Hash{key}, []uint8{1, 2}, pair, true))
+		require.NoError(t, err)
+		require.Equal(t, "Complex(string,uint8[],(address,bytes4),bool)", event.Signature)
+		require.Equal(t, "(address,bytes4)", event.Args[2].Type)
+		requireArgs(t, event, map[string]string{
+			"key":    `"` + key.Hex() + `"`,
+			"values": `["1","2"]`,
+			"pair":   `{"owner":"` + from.Hex() + `","id":"0x01020304"}`,
+			"arg3":   `true`, // 이름이 없는 인자
+		})
+
+		// protobuf
+		decoded := logabi.EventFromProtobuf(logabi.EventToProtobuf(event))
+		require.Equal(t, event, decoded)
+		bytes, err := json.Marshal(decoded)
+		require.NoError(t, err)
+		require.Contains(t, string(bytes), `"name":"values","type":"uint8[]","indexed":false,"value":["1","2"]`)
+	})
+	_, err = logabi.ParseABI([]byte(`{"contractName":"Empty"}`))
+	require.Error(t, err)
+}
@@ -0,0 +1,148 @@
+package logabi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrUnknownEvent 는 로그의 주소나 이벤트 시그니처로 등록된 ABI 가 없을 때 반환된다.
+var ErrUnknownEvent = errors.New("unknown event")
+
+// DecodeError 는 등록된 이벤트로 로그를 디코딩하지 못했을 때 반환된다.
+type DecodeError struct {
+	Signature string // ex: Transfer(address,address,uint256)
+	Err       error
+}
+
+func (e *DecodeError) Error() string {
+	return e.Signature + ": " + e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Registry 는 로그를 디코딩하기 위한 이벤트 ABI 목록이다.
+// 주소로 등록된 ABI 는 해당 주소의 로그에만 사용하고, 주소 없이 등록된 ABI 는 이벤트 시그니처(topic0)로 모든 로그에 사용한다.
+// 같은 시그니처로 여러 이벤트가 등록되면 (ex: ERC20/ERC721 Transfer) 디코딩에 성공하는 이벤트를 사용한다.
+type Registry struct {
+	lock        sync.RWMutex
+	byAddress   map[common.Address]map[common.Hash]abi.Event
+	bySignature map[common.Hash][]abi.Event
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		byAddress:   make(map[common.Address]map[common.Hash]abi.Event),
+		bySignature: make(map[common.Hash][]abi.Event),
+	}
+}
+
+// ParseABI 는 ABI JSON 배열이나, "abi" 필드를 가진 컴파일 결과(hardhat artifact)를 읽는다.
+func ParseABI(data []byte) (abi.ABI, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) != 0 && data[0] == '{' {
+		artifact := struct {
+			ABI json.RawMessage `json:"abi"`
+		}{}
+		if err := json.Unmarshal(data, &artifact); err != nil {
+			return abi.ABI{}, err
+		}
+		if len(artifact.ABI) == 0 {
+			return abi.ABI{}, errors.New("abi field is not found")
+		}
+		data = artifact.ABI
+	}
+	return abi.JSON(bytes.NewReader(data))
+}
+
+// LoadFile 은 파일의 ABI 를 등록한다. (ParseABI, Register)
+func (r *Registry) LoadFile(path string, address *common.Address) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	contractABI, err := ParseABI(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return r.Register(address, contractABI), nil
+}
+
+// Register 는 ABI 의 이벤트들을 등록하고, 등록된 이벤트의 시그니처를 반환한다.
+// address 가 nil 이면 이벤트 시그니처로 등록한다. 익명 이벤트는 topic0 로 구분할 수 없어서 등록하지 않는다.
+func (r *Registry) Register(address *common.Address, contractABI abi.ABI) []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	signatures := []string{}
+	for _, event := range contractABI.Events {
+		if event.Anonymous {
+			continue
+		}
+		if address != nil {
+			events, ok := r.byAddress[*address]
+			if !ok {
+				events = make(map[common.Hash]abi.Event)
+				r.byAddress[*address] = events
+			}
+			events[event.ID] = event
+		} else if !r.registered(event) {
+			r.bySignature[event.ID] = append(r.bySignature[event.ID], event)
+		}
+		signatures = append(signatures, event.Sig)
+	}
+	sort.Strings(signatures)
+	return signatures
+}
+
+// registered 는 인덱스 여부까지 같은 이벤트가 이미 시그니처로 등록되었는지 확인한다.
+func (r *Registry) registered(event abi.Event) bool {
+	for _, e := range r.bySignature[event.ID] {
+		if e.String() == event.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// Decode 는 등록된 ABI 로 로그를 디코딩한다. 등록된 ABI 가 없으면 ErrUnknownEvent 를,
+// 등록된 이벤트로 디코딩하지 못하면 *DecodeError 를 반환한다.
+func (r *Registry) Decode(log types.Log) (*Event, error) {
+	if len(log.Topics) == 0 {
+		return nil, ErrUnknownEvent
+	}
+	r.lock.RLock()
+	event, ok := r.byAddress[log.Address][log.Topics[0]]
+	candidates := r.bySignature[log.Topics[0]]
+	r.lock.RUnlock()
+
+	if ok {
+		decoded, err := DecodeEvent(event, log)
+		if err != nil {
+			return nil, &DecodeError{Signature: event.Sig, Err: err}
+		}
+		return decoded, nil
+	}
+	if len(candidates) == 0 {
+		return nil, ErrUnknownEvent
+	}
+	var err error
+	for _, event := range candidates {
+		var decoded *Event
+		if decoded, err = DecodeEvent(event, log); err == nil {
+			return decoded, nil
+		}
+		err = &DecodeError{Signature: event.Sig, Err: err}
+	}
+	return nil, err
+}
@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -31,6 +32,13 @@ type Log struct {
 	Topics  [][]byte `protobuf:"bytes,3,rep,name=topics,proto3" json:"topics,omitempty"`
 	Data    []byte   `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
 	Removed bool     `protobuf:"varint,5,opt,name=removed,proto3" json:"removed,omitempty"`
+	// 전송 큐가 가득 차서 이 로그 이전에 전달되지 못하고 버려진 로그 수 (overflow-policy = "drop")
+	Dropped uint64 `protobuf:"varint,6,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	ChainId uint64 `protobuf:"varint,7,opt,name=chainId,proto3" json:"chainId,omitempty"`
+	// 로그 보강(enrich)이 설정된 체인에서만 설정된다.
+	Context *LogContext `protobuf:"bytes,8,opt,name=context,proto3" json:"context,omitempty"`
+	// 요청의 decode 가 설정되고, 등록된 ABI 로 디코딩된 로그에서만 설정된다.
+	Event *DecodedEvent `protobuf:"bytes,9,opt,name=event,proto3" json:"event,omitempty"`
 }
 
 func (x *Log) Reset() {
@@ -100,18 +108,265 @@ func (x *Log) GetRemoved() bool {
 	return false
 }
 
+func (x *Log) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+func (x *Log) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *Log) GetContext() *LogContext {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *Log) GetEvent() *DecodedEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+// 등록된 ABI 로 디코딩된 이벤트
+type DecodedEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Signature string      `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"` // ex: Transfer(address,address,uint256)
+	Args      []*EventArg `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`           // ABI 에 정의된 순서
+}
+
+func (x *DecodedEvent) Reset() {
+	*x = DecodedEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecodedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecodedEvent) ProtoMessage() {}
+
+func (x *DecodedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecodedEvent.ProtoReflect.Descriptor instead.
+func (*DecodedEvent) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DecodedEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DecodedEvent) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *DecodedEvent) GetArgs() []*EventArg {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+type EventArg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type    string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // ABI 타입 (ex: uint256, address, (uint256,bytes)[])
+	Indexed bool   `protobuf:"varint,3,opt,name=indexed,proto3" json:"indexed,omitempty"`
+	// JSON 으로 인코딩된 값. 정수는 10진수 문자열, address/bytes/hash 는 0x 로 시작하는 hex 문자열,
+	// 배열은 배열, 튜플은 이름을 키로 하는 객체이다. indexed 인 동적 타입(string, bytes, 배열, 튜플)은 값의 keccak256 해시이다.
+	Value string `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *EventArg) Reset() {
+	*x = EventArg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventArg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventArg) ProtoMessage() {}
+
+func (x *EventArg) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventArg.ProtoReflect.Descriptor instead.
+func (*EventArg) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EventArg) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EventArg) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *EventArg) GetIndexed() bool {
+	if x != nil {
+		return x.Indexed
+	}
+	return false
+}
+
+func (x *EventArg) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// 로그가 포함된 블록과 트랜잭션의 정보
+type LogContext struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockTimestamp uint64 `protobuf:"varint,1,opt,name=blockTimestamp,proto3" json:"blockTimestamp,omitempty"`
+	From           []byte `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To             []byte `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"` // 컨트랙트 생성 트랜잭션은 빈 값
+	Status         uint64 `protobuf:"varint,4,opt,name=status,proto3" json:"status,omitempty"`
+	GasUsed        uint64 `protobuf:"varint,5,opt,name=gasUsed,proto3" json:"gasUsed,omitempty"`
+}
+
+func (x *LogContext) Reset() {
+	*x = LogContext{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogContext) ProtoMessage() {}
+
+func (x *LogContext) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogContext.ProtoReflect.Descriptor instead.
+func (*LogContext) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LogContext) GetBlockTimestamp() uint64 {
+	if x != nil {
+		return x.BlockTimestamp
+	}
+	return 0
+}
+
+func (x *LogContext) GetFrom() []byte {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *LogContext) GetTo() []byte {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *LogContext) GetStatus() uint64 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *LogContext) GetGasUsed() uint64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
 type InfoResMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Address [][]byte `protobuf:"bytes,1,rep,name=address,proto3" json:"address,omitempty"`
+	Address [][]byte     `protobuf:"bytes,1,rep,name=address,proto3" json:"address,omitempty"`  // 기본 체인의 주소 목록
+	ChainId uint64       `protobuf:"varint,2,opt,name=chainId,proto3" json:"chainId,omitempty"` // 기본 체인
+	Chains  []*ChainInfo `protobuf:"bytes,3,rep,name=chains,proto3" json:"chains,omitempty"`
 }
 
 func (x *InfoResMessage) Reset() {
 	*x = InfoResMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logger_proto_msgTypes[1]
+		mi := &file_logger_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -124,7 +379,7 @@ func (x *InfoResMessage) String() string {
 func (*InfoResMessage) ProtoMessage() {}
 
 func (x *InfoResMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_logger_proto_msgTypes[1]
+	mi := &file_logger_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -137,7 +392,7 @@ func (x *InfoResMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InfoResMessage.ProtoReflect.Descriptor instead.
 func (*InfoResMessage) Descriptor() ([]byte, []int) {
-	return file_logger_proto_rawDescGZIP(), []int{1}
+	return file_logger_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *InfoResMessage) GetAddress() [][]byte {
@@ -147,32 +402,46 @@ func (x *InfoResMessage) GetAddress() [][]byte {
 	return nil
 }
 
-type ConnectReqMessage struct {
+func (x *InfoResMessage) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *InfoResMessage) GetChains() []*ChainInfo {
+	if x != nil {
+		return x.Chains
+	}
+	return nil
+}
+
+type ChainInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	FromBlock uint64 `protobuf:"varint,1,opt,name=fromBlock,proto3" json:"fromBlock,omitempty"`
-	Address   []byte `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	ChainId uint64   `protobuf:"varint,1,opt,name=chainId,proto3" json:"chainId,omitempty"`
+	Address [][]byte `protobuf:"bytes,2,rep,name=address,proto3" json:"address,omitempty"`
 }
 
-func (x *ConnectReqMessage) Reset() {
-	*x = ConnectReqMessage{}
+func (x *ChainInfo) Reset() {
+	*x = ChainInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logger_proto_msgTypes[2]
+		mi := &file_logger_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ConnectReqMessage) String() string {
+func (x *ChainInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConnectReqMessage) ProtoMessage() {}
+func (*ChainInfo) ProtoMessage() {}
 
-func (x *ConnectReqMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_logger_proto_msgTypes[2]
+func (x *ChainInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -183,50 +452,1168 @@ func (x *ConnectReqMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConnectReqMessage.ProtoReflect.Descriptor instead.
-func (*ConnectReqMessage) Descriptor() ([]byte, []int) {
-	return file_logger_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ChainInfo.ProtoReflect.Descriptor instead.
+func (*ChainInfo) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ConnectReqMessage) GetFromBlock() uint64 {
+func (x *ChainInfo) GetChainId() uint64 {
 	if x != nil {
-		return x.FromBlock
+		return x.ChainId
 	}
 	return 0
 }
 
-func (x *ConnectReqMessage) GetAddress() []byte {
+func (x *ChainInfo) GetAddress() [][]byte {
 	if x != nil {
 		return x.Address
 	}
 	return nil
 }
 
-type BlockNumberMessage struct {
+type ChainReqMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BlockNumber uint64 `protobuf:"varint,1,opt,name=blockNumber,proto3" json:"blockNumber,omitempty"`
+	ChainId uint64 `protobuf:"varint,1,opt,name=chainId,proto3" json:"chainId,omitempty"`
 }
 
-func (x *BlockNumberMessage) Reset() {
-	*x = BlockNumberMessage{}
+func (x *ChainReqMessage) Reset() {
+	*x = ChainReqMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChainReqMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChainReqMessage) ProtoMessage() {}
+
+func (x *ChainReqMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChainReqMessage.ProtoReflect.Descriptor instead.
+func (*ChainReqMessage) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ChainReqMessage) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+// 하나의 토픽 위치에 대한 OR 목록. 빈 목록은 모든 토픽과 일치한다.
+// (ethereum.FilterQuery.Topics 와 같은 의미를 가진다.)
+type Topics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Topic [][]byte `protobuf:"bytes,1,rep,name=topic,proto3" json:"topic,omitempty"`
+}
+
+func (x *Topics) Reset() {
+	*x = Topics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Topics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Topics) ProtoMessage() {}
+
+func (x *Topics) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Topics.ProtoReflect.Descriptor instead.
+func (*Topics) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Topics) GetTopic() [][]byte {
+	if x != nil {
+		return x.Topic
+	}
+	return nil
+}
+
+type ConnectReqMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromBlock uint64    `protobuf:"varint,1,opt,name=fromBlock,proto3" json:"fromBlock,omitempty"`
+	Address   []byte    `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Topics    []*Topics `protobuf:"bytes,3,rep,name=topics,proto3" json:"topics,omitempty"`
+	// address 와 함께 구독할 주소 목록. 로그는 (block, logIndex) 순서로 전달된다.
+	Addresses [][]byte `protobuf:"bytes,4,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	// 설정되면 fromBlock 대신 해당 위치 이후의 로그부터 전달한다.
+	ResumeAfter *Cursor `protobuf:"bytes,5,opt,name=resumeAfter,proto3" json:"resumeAfter,omitempty"`
+	ChainId     uint64  `protobuf:"varint,6,opt,name=chainId,proto3" json:"chainId,omitempty"`
+	// 설정되면 등록된 ABI 로 디코딩한 이벤트를 함께 전달한다.
+	Decode bool `protobuf:"varint,7,opt,name=decode,proto3" json:"decode,omitempty"`
+}
+
+func (x *ConnectReqMessage) Reset() {
+	*x = ConnectReqMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConnectReqMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectReqMessage) ProtoMessage() {}
+
+func (x *ConnectReqMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectReqMessage.ProtoReflect.Descriptor instead.
+func (*ConnectReqMessage) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ConnectReqMessage) GetFromBlock() uint64 {
+	if x != nil {
+		return x.FromBlock
+	}
+	return 0
+}
+
+func (x *ConnectReqMessage) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *ConnectReqMessage) GetTopics() []*Topics {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *ConnectReqMessage) GetAddresses() [][]byte {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *ConnectReqMessage) GetResumeAfter() *Cursor {
+	if x != nil {
+		return x.ResumeAfter
+	}
+	return nil
+}
+
+func (x *ConnectReqMessage) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *ConnectReqMessage) GetDecode() bool {
+	if x != nil {
+		return x.Decode
+	}
+	return false
+}
+
+type Cursor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockNumber uint64 `protobuf:"varint,1,opt,name=blockNumber,proto3" json:"blockNumber,omitempty"`
+	Index       uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (x *Cursor) Reset() {
+	*x = Cursor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cursor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cursor) ProtoMessage() {}
+
+func (x *Cursor) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cursor.ProtoReflect.Descriptor instead.
+func (*Cursor) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Cursor) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *Cursor) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type GetLogsReqMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Addresses [][]byte  `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"` // 빈 목록은 모든 주소
+	Topics    []*Topics `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
+	FromBlock uint64    `protobuf:"varint,3,opt,name=fromBlock,proto3" json:"fromBlock,omitempty"`
+	ToBlock   uint64    `protobuf:"varint,4,opt,name=toBlock,proto3" json:"toBlock,omitempty"`    // 0 이면 마지막 블록까지
+	PageSize  uint32    `protobuf:"varint,5,opt,name=pageSize,proto3" json:"pageSize,omitempty"`  // 0 이면 100, 최대 1000
+	PageToken string    `protobuf:"bytes,6,opt,name=pageToken,proto3" json:"pageToken,omitempty"` // 이전 응답의 nextPageToken
+	ChainId   uint64    `protobuf:"varint,7,opt,name=chainId,proto3" json:"chainId,omitempty"`
+	Decode    bool      `protobuf:"varint,8,opt,name=decode,proto3" json:"decode,omitempty"` // ConnectReqMessage.decode
+}
+
+func (x *GetLogsReqMessage) Reset() {
+	*x = GetLogsReqMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLogsReqMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogsReqMessage) ProtoMessage() {}
+
+func (x *GetLogsReqMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogsReqMessage.ProtoReflect.Descriptor instead.
+func (*GetLogsReqMessage) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetLogsReqMessage) GetAddresses() [][]byte {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *GetLogsReqMessage) GetTopics() []*Topics {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *GetLogsReqMessage) GetFromBlock() uint64 {
+	if x != nil {
+		return x.FromBlock
+	}
+	return 0
+}
+
+func (x *GetLogsReqMessage) GetToBlock() uint64 {
+	if x != nil {
+		return x.ToBlock
+	}
+	return 0
+}
+
+func (x *GetLogsReqMessage) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetLogsReqMessage) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetLogsReqMessage) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *GetLogsReqMessage) GetDecode() bool {
+	if x != nil {
+		return x.Decode
+	}
+	return false
+}
+
+type GetLogsResMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Logs          []*Log `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	NextPageToken string `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // 빈 값이면 마지막 페이지
+}
+
+func (x *GetLogsResMessage) Reset() {
+	*x = GetLogsResMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLogsResMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogsResMessage) ProtoMessage() {}
+
+func (x *GetLogsResMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogsResMessage.ProtoReflect.Descriptor instead.
+func (*GetLogsResMessage) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetLogsResMessage) GetLogs() []*Log {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *GetLogsResMessage) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type BlockNumberMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockNumber uint64 `protobuf:"varint,1,opt,name=blockNumber,proto3" json:"blockNumber,omitempty"`
+	ChainId     uint64 `protobuf:"varint,2,opt,name=chainId,proto3" json:"chainId,omitempty"`
+}
+
+func (x *BlockNumberMessage) Reset() {
+	*x = BlockNumberMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockNumberMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockNumberMessage) ProtoMessage() {}
+
+func (x *BlockNumberMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockNumberMessage.ProtoReflect.Descriptor instead.
+func (*BlockNumberMessage) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BlockNumberMessage) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *BlockNumberMessage) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+type AddressReqMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Add: 설정되면 fromBlock 부터 추가 시점까지의 과거 로그를 백그라운드에서 수집한다.
+	// 수집된 과거 로그는 실시간으로 전달되지 않으며, Connect/GetLogs 의 히스토리로 조회된다.
+	FromBlock uint64 `protobuf:"varint,2,opt,name=fromBlock,proto3" json:"fromBlock,omitempty"`
+	// Add: 주소의 설명
+	Label   string `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	ChainId uint64 `protobuf:"varint,4,opt,name=chainId,proto3" json:"chainId,omitempty"`
+}
+
+func (x *AddressReqMessage) Reset() {
+	*x = AddressReqMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressReqMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressReqMessage) ProtoMessage() {}
+
+func (x *AddressReqMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressReqMessage.ProtoReflect.Descriptor instead.
+func (*AddressReqMessage) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AddressReqMessage) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *AddressReqMessage) GetFromBlock() uint64 {
+	if x != nil {
+		return x.FromBlock
+	}
+	return 0
+}
+
+func (x *AddressReqMessage) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *AddressReqMessage) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+type WatchedAddress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address    []byte                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Label      string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	AddedBlock uint64                 `protobuf:"varint,3,opt,name=addedBlock,proto3" json:"addedBlock,omitempty"` // 이 블록부터 로그를 수집한다.
+	AddedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=addedAt,proto3" json:"addedAt,omitempty"`
+	Active     bool                   `protobuf:"varint,5,opt,name=active,proto3" json:"active,omitempty"` // Remove 된 주소는 false
+}
+
+func (x *WatchedAddress) Reset() {
+	*x = WatchedAddress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchedAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchedAddress) ProtoMessage() {}
+
+func (x *WatchedAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchedAddress.ProtoReflect.Descriptor instead.
+func (*WatchedAddress) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WatchedAddress) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *WatchedAddress) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *WatchedAddress) GetAddedBlock() uint64 {
+	if x != nil {
+		return x.AddedBlock
+	}
+	return 0
+}
+
+func (x *WatchedAddress) GetAddedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AddedAt
+	}
+	return nil
+}
+
+func (x *WatchedAddress) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+type ListResMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Addresses []*WatchedAddress `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+}
+
+func (x *ListResMessage) Reset() {
+	*x = ListResMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListResMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResMessage) ProtoMessage() {}
+
+func (x *ListResMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResMessage.ProtoReflect.Descriptor instead.
+func (*ListResMessage) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListResMessage) GetAddresses() []*WatchedAddress {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type ABIReqMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Abi string `protobuf:"bytes,1,opt,name=abi,proto3" json:"abi,omitempty"` // ABI JSON 배열, 또는 "abi" 필드를 가진 컴파일 결과(hardhat artifact)
+	// 설정되면 해당 주소의 로그에만 사용한다. 설정되지 않으면 이벤트 시그니처(topic0)로 모든 주소의 로그에 사용한다.
+	Address []byte `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *ABIReqMessage) Reset() {
+	*x = ABIReqMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ABIReqMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ABIReqMessage) ProtoMessage() {}
+
+func (x *ABIReqMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ABIReqMessage.ProtoReflect.Descriptor instead.
+func (*ABIReqMessage) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ABIReqMessage) GetAbi() string {
+	if x != nil {
+		return x.Abi
+	}
+	return ""
+}
+
+func (x *ABIReqMessage) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type ABIResMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Events []string `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"` // 등록된 이벤트의 시그니처
+}
+
+func (x *ABIResMessage) Reset() {
+	*x = ABIResMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ABIResMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ABIResMessage) ProtoMessage() {}
+
+func (x *ABIResMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ABIResMessage.ProtoReflect.Descriptor instead.
+func (*ABIResMessage) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ABIResMessage) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type StatusResMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChainId       uint64            `protobuf:"varint,1,opt,name=chainId,proto3" json:"chainId,omitempty"`
+	HeadBlock     uint64            `protobuf:"varint,2,opt,name=headBlock,proto3" json:"headBlock,omitempty"` // 노드의 최신 블록 (노드를 호출할 수 없으면 마지막으로 받은 헤드)
+	ScanBlock     uint64            `protobuf:"varint,3,opt,name=scanBlock,proto3" json:"scanBlock,omitempty"` // 로그 수집이 완료된 마지막 블록 (체크포인트)
+	Lag           uint64            `protobuf:"varint,4,opt,name=lag,proto3" json:"lag,omitempty"`             // headBlock - scanBlock (confirmations 를 포함한다)
+	Running       bool              `protobuf:"varint,5,opt,name=running,proto3" json:"running,omitempty"`     // Stop 되었거나 Start 되지 않았으면 false
+	Confirmations uint64            `protobuf:"varint,6,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	Addresses     []*AddressStatus  `protobuf:"bytes,7,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Clients       []*ClientStatus   `protobuf:"bytes,8,rep,name=clients,proto3" json:"clients,omitempty"`
+	Errors        []*ErrorStatus    `protobuf:"bytes,9,rep,name=errors,proto3" json:"errors,omitempty"`        // 최근 에러, 발생한 순서
+	Endpoints     []*EndpointStatus `protobuf:"bytes,10,rep,name=endpoints,proto3" json:"endpoints,omitempty"` // 노드 엔드포인트 (failover 를 사용할 때만 설정된다)
+	Webhooks      []*WebhookStatus  `protobuf:"bytes,11,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+}
+
+func (x *StatusResMessage) Reset() {
+	*x = StatusResMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResMessage) ProtoMessage() {}
+
+func (x *StatusResMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResMessage.ProtoReflect.Descriptor instead.
+func (*StatusResMessage) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StatusResMessage) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *StatusResMessage) GetHeadBlock() uint64 {
+	if x != nil {
+		return x.HeadBlock
+	}
+	return 0
+}
+
+func (x *StatusResMessage) GetScanBlock() uint64 {
+	if x != nil {
+		return x.ScanBlock
+	}
+	return 0
+}
+
+func (x *StatusResMessage) GetLag() uint64 {
+	if x != nil {
+		return x.Lag
+	}
+	return 0
+}
+
+func (x *StatusResMessage) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *StatusResMessage) GetConfirmations() uint64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *StatusResMessage) GetAddresses() []*AddressStatus {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *StatusResMessage) GetClients() []*ClientStatus {
+	if x != nil {
+		return x.Clients
+	}
+	return nil
+}
+
+func (x *StatusResMessage) GetErrors() []*ErrorStatus {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *StatusResMessage) GetEndpoints() []*EndpointStatus {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+func (x *StatusResMessage) GetWebhooks() []*WebhookStatus {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+type AddressStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address     *WatchedAddress `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	LogCount    uint64          `protobuf:"varint,2,opt,name=logCount,proto3" json:"logCount,omitempty"`       // 저장된 로그 수
+	Backfilling bool            `protobuf:"varint,3,opt,name=backfilling,proto3" json:"backfilling,omitempty"` // Add 의 과거 로그를 수집중
+}
+
+func (x *AddressStatus) Reset() {
+	*x = AddressStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressStatus) ProtoMessage() {}
+
+func (x *AddressStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressStatus.ProtoReflect.Descriptor instead.
+func (*AddressStatus) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AddressStatus) GetAddress() *WatchedAddress {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *AddressStatus) GetLogCount() uint64 {
+	if x != nil {
+		return x.LogCount
+	}
+	return 0
+}
+
+func (x *AddressStatus) GetBackfilling() bool {
+	if x != nil {
+		return x.Backfilling
+	}
+	return false
+}
+
+type ClientStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Addresses     [][]byte               `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	QueueLength   uint32                 `protobuf:"varint,3,opt,name=queueLength,proto3" json:"queueLength,omitempty"` // 전송 큐에 쌓인 로그 수
+	QueueCapacity uint32                 `protobuf:"varint,4,opt,name=queueCapacity,proto3" json:"queueCapacity,omitempty"`
+	Dropped       uint64                 `protobuf:"varint,5,opt,name=dropped,proto3" json:"dropped,omitempty"` // 아직 알리지 않은 버려진 로그 수
+	ConnectedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=connectedAt,proto3" json:"connectedAt,omitempty"`
+	Decode        bool                   `protobuf:"varint,7,opt,name=decode,proto3" json:"decode,omitempty"`
+}
+
+func (x *ClientStatus) Reset() {
+	*x = ClientStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientStatus) ProtoMessage() {}
+
+func (x *ClientStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientStatus.ProtoReflect.Descriptor instead.
+func (*ClientStatus) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ClientStatus) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ClientStatus) GetAddresses() [][]byte {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *ClientStatus) GetQueueLength() uint32 {
+	if x != nil {
+		return x.QueueLength
+	}
+	return 0
+}
+
+func (x *ClientStatus) GetQueueCapacity() uint32 {
+	if x != nil {
+		return x.QueueCapacity
+	}
+	return 0
+}
+
+func (x *ClientStatus) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+func (x *ClientStatus) GetConnectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConnectedAt
+	}
+	return nil
+}
+
+func (x *ClientStatus) GetDecode() bool {
+	if x != nil {
+		return x.Decode
+	}
+	return false
+}
+
+type ErrorStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time      *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Operation string                 `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	Message   string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ErrorStatus) Reset() {
+	*x = ErrorStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_logger_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErrorStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorStatus) ProtoMessage() {}
+
+func (x *ErrorStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorStatus.ProtoReflect.Descriptor instead.
+func (*ErrorStatus) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ErrorStatus) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ErrorStatus) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *ErrorStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type EndpointStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uri         string                 `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	Active      bool                   `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`     // 현재 사용중인 엔드포인트
+	Healthy     bool                   `protobuf:"varint,3,opt,name=healthy,proto3" json:"healthy,omitempty"`   // 마지막 호출이 성공했다.
+	Failures    uint32                 `protobuf:"varint,4,opt,name=failures,proto3" json:"failures,omitempty"` // 연속으로 실패한 횟수
+	LastError   string                 `protobuf:"bytes,5,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	LastErrorAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=lastErrorAt,proto3" json:"lastErrorAt,omitempty"`
+}
+
+func (x *EndpointStatus) Reset() {
+	*x = EndpointStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logger_proto_msgTypes[3]
+		mi := &file_logger_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BlockNumberMessage) String() string {
+func (x *EndpointStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BlockNumberMessage) ProtoMessage() {}
+func (*EndpointStatus) ProtoMessage() {}
 
-func (x *BlockNumberMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_logger_proto_msgTypes[3]
+func (x *EndpointStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -237,43 +1624,80 @@ func (x *BlockNumberMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BlockNumberMessage.ProtoReflect.Descriptor instead.
-func (*BlockNumberMessage) Descriptor() ([]byte, []int) {
-	return file_logger_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use EndpointStatus.ProtoReflect.Descriptor instead.
+func (*EndpointStatus) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *BlockNumberMessage) GetBlockNumber() uint64 {
+func (x *EndpointStatus) GetUri() string {
 	if x != nil {
-		return x.BlockNumber
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *EndpointStatus) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *EndpointStatus) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *EndpointStatus) GetFailures() uint32 {
+	if x != nil {
+		return x.Failures
 	}
 	return 0
 }
 
-type AddressReqMessage struct {
+func (x *EndpointStatus) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *EndpointStatus) GetLastErrorAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastErrorAt
+	}
+	return nil
+}
+
+type WebhookStatus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Name   string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url    string  `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Cursor *Cursor `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"` // 저장된 전달 위치
 }
 
-func (x *AddressReqMessage) Reset() {
-	*x = AddressReqMessage{}
+func (x *WebhookStatus) Reset() {
+	*x = WebhookStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logger_proto_msgTypes[4]
+		mi := &file_logger_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AddressReqMessage) String() string {
+func (x *WebhookStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddressReqMessage) ProtoMessage() {}
+func (*WebhookStatus) ProtoMessage() {}
 
-func (x *AddressReqMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_logger_proto_msgTypes[4]
+func (x *WebhookStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_logger_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -284,14 +1708,28 @@ func (x *AddressReqMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddressReqMessage.ProtoReflect.Descriptor instead.
-func (*AddressReqMessage) Descriptor() ([]byte, []int) {
-	return file_logger_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use WebhookStatus.ProtoReflect.Descriptor instead.
+func (*WebhookStatus) Descriptor() ([]byte, []int) {
+	return file_logger_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *AddressReqMessage) GetAddress() []byte {
+func (x *WebhookStatus) GetName() string {
 	if x != nil {
-		return x.Address
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WebhookStatus) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookStatus) GetCursor() *Cursor {
+	if x != nil {
+		return x.Cursor
 	}
 	return nil
 }
@@ -311,7 +1749,7 @@ type Log_Raw struct {
 func (x *Log_Raw) Reset() {
 	*x = Log_Raw{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_logger_proto_msgTypes[5]
+		mi := &file_logger_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -324,7 +1762,7 @@ func (x *Log_Raw) String() string {
 func (*Log_Raw) ProtoMessage() {}
 
 func (x *Log_Raw) ProtoReflect() protoreflect.Message {
-	mi := &file_logger_proto_msgTypes[5]
+	mi := &file_logger_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -381,64 +1819,268 @@ var file_logger_proto_rawDesc = []byte{
 	0x0a, 0x0c, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
 	0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0x98, 0x02, 0x0a, 0x03, 0x4c, 0x6f, 0x67, 0x12, 0x21, 0x0a, 0x03, 0x72,
-	0x61, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65,
-	0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x2e, 0x52, 0x61, 0x77, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x18,
-	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x70, 0x69,
-	0x63, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73,
-	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
-	0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x1a, 0x8d,
-	0x01, 0x0a, 0x03, 0x52, 0x61, 0x77, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e,
-	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f,
-	0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x6c, 0x6f, 0x63,
-	0x6b, 0x48, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x6c, 0x6f,
-	0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06,
-	0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x78,
-	0x48, 0x61, 0x73, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x78, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x74, 0x78, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x2a,
-	0x0a, 0x0e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x4b, 0x0a, 0x11, 0x43, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
-	0x1c, 0x0a, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x09, 0x66, 0x72, 0x6f, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x18, 0x0a,
-	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
-	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x36, 0x0a, 0x12, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x20, 0x0a,
-	0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22,
-	0x2d, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x32, 0x79,
-	0x0a, 0x06, 0x4c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x04, 0x49, 0x6e, 0x66, 0x6f,
-	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65,
-	0x72, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x22, 0x00, 0x12, 0x35, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x19, 0x2e,
-	0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65,
-	0x71, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x0b, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65,
-	0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x22, 0x00, 0x30, 0x01, 0x32, 0x87, 0x02, 0x0a, 0x05, 0x41, 0x64,
-	0x6d, 0x69, 0x6e, 0x12, 0x3e, 0x0a, 0x03, 0x41, 0x64, 0x64, 0x12, 0x19, 0x2e, 0x6c, 0x6f, 0x67,
-	0x67, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x1a, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x06, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x19, 0x2e,
-	0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65,
-	0x71, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x1a, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65,
-	0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12,
-	0x1a, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x04, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1a, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x42,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa6, 0x03, 0x0a, 0x03, 0x4c, 0x6f, 0x67, 0x12, 0x21, 0x0a, 0x03,
+	0x72, 0x61, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6c, 0x6f, 0x67, 0x67,
+	0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x2e, 0x52, 0x61, 0x77, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x70,
+	0x69, 0x63, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x49, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x6f,
+	0x67, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x12, 0x2a, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65,
+	0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x1a, 0x8d, 0x01,
+	0x0a, 0x03, 0x52, 0x61, 0x77, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x48, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x74,
+	0x78, 0x48, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x78, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x78, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x74, 0x78, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x66, 0x0a,
+	0x0c, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12,
+	0x24, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41, 0x72, 0x67, 0x52,
+	0x04, 0x61, 0x72, 0x67, 0x73, 0x22, 0x62, 0x0a, 0x08, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41, 0x72,
+	0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x8a, 0x01, 0x0a, 0x0a, 0x4c, 0x6f,
+	0x67, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x02, 0x74, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x67, 0x61, 0x73, 0x55, 0x73, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x67,
+	0x61, 0x73, 0x55, 0x73, 0x65, 0x64, 0x22, 0x6f, 0x0a, 0x0e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65,
+	0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x06,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6c,
+	0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x06, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x22, 0x3f, 0x0a, 0x09, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x2b, 0x0a, 0x0f, 0x43, 0x68, 0x61, 0x69,
+	0x6e, 0x52, 0x65, 0x71, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x49, 0x64, 0x22, 0x1e, 0x0a, 0x06, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05,
+	0x74, 0x6f, 0x70, 0x69, 0x63, 0x22, 0xf5, 0x01, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x52, 0x65, 0x71, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x66,
+	0x72, 0x6f, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x66, 0x72, 0x6f, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x26, 0x0a, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x54, 0x6f, 0x70,
+	0x69, 0x63, 0x73, 0x52, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x0b, 0x72, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x41, 0x66, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x52, 0x0b,
+	0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x40, 0x0a,
+	0x06, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22,
+	0xfd, 0x01, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x54, 0x6f, 0x70,
+	0x69, 0x63, 0x73, 0x52, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x66,
+	0x72, 0x6f, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x66, 0x72, 0x6f, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x6f, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74, 0x6f, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x22,
+	0x5a, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x52,
+	0x04, 0x6c, 0x6f, 0x67, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65,
+	0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x50, 0x0a, 0x12, 0x42,
 	0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x22, 0x00, 0x42, 0x0b, 0x5a, 0x09, 0x2e, 0x2e, 0x2f, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x22, 0x7b, 0x0a,
+	0x11, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x66, 0x72, 0x6f, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x66, 0x72, 0x6f, 0x6d, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x22, 0xae, 0x01, 0x0a, 0x0e, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x1e, 0x0a,
+	0x0a, 0x61, 0x64, 0x64, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x61, 0x64, 0x64, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x34, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x65, 0x64, 0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x61, 0x64, 0x64, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x22, 0x46, 0x0a, 0x0e, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x34, 0x0a,
+	0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x22, 0x3b, 0x0a, 0x0d, 0x41, 0x42, 0x49, 0x52, 0x65, 0x71, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x62, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x61, 0x62, 0x69, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x22, 0x27, 0x0a, 0x0d, 0x41, 0x42, 0x49, 0x52, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xb5, 0x03, 0x0a, 0x10, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x68, 0x65, 0x61, 0x64,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x68, 0x65, 0x61,
+	0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x63, 0x61, 0x6e, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x63, 0x61, 0x6e, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x61, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x03, 0x6c, 0x61, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e,
+	0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x6f, 0x67, 0x67,
+	0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c,
+	0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x07, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x06, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x6f,
+	0x67, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x34, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x6f,
+	0x67, 0x67, 0x65, 0x72, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x31,
+	0x0a, 0x08, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x08, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b,
+	0x73, 0x22, 0x7f, 0x0a, 0x0d, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x30, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x65, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x20, 0x0a, 0x0b, 0x62, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x62, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x69,
+	0x6e, 0x67, 0x22, 0xf4, 0x01, 0x0a, 0x0c, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x12, 0x20, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x71, 0x75, 0x65, 0x75, 0x65, 0x4c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x12, 0x24, 0x0a, 0x0d, 0x71, 0x75, 0x65, 0x75, 0x65, 0x43, 0x61, 0x70, 0x61,
+	0x63, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f,
+	0x70, 0x70, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70,
+	0x70, 0x65, 0x64, 0x12, 0x3c, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x64, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x75, 0x0a, 0x0b, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0xcc, 0x01, 0x0a, 0x0e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x75, 0x72, 0x69, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x3c, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x41, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x41, 0x74, 0x22,
+	0x5d, 0x0a, 0x0d, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e,
+	0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x32, 0xbc,
+	0x01, 0x0a, 0x06, 0x4c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x04, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x6c, 0x6f, 0x67, 0x67,
+	0x65, 0x72, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x19,
+	0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52,
+	0x65, 0x71, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x0b, 0x2e, 0x6c, 0x6f, 0x67, 0x67,
+	0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x67, 0x22, 0x00, 0x30, 0x01, 0x12, 0x41, 0x0a, 0x07, 0x47, 0x65,
+	0x74, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x19, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x47,
+	0x65, 0x74, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x1a, 0x19, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67,
+	0x73, 0x52, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x32, 0xbc, 0x03,
+	0x0a, 0x05, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x3e, 0x0a, 0x03, 0x41, 0x64, 0x64, 0x12, 0x19,
+	0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x65, 0x71, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x1a, 0x2e, 0x6c, 0x6f, 0x67, 0x67,
+	0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x06, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x12, 0x19, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x71, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x1a, 0x2e, 0x6c,
+	0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x05, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x1a, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x04, 0x53, 0x74, 0x6f,
+	0x70, 0x12, 0x17, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x52, 0x65, 0x71, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x1a, 0x2e, 0x6c, 0x6f, 0x67,
+	0x67, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x17, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52,
+	0x65, 0x71, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x16, 0x2e, 0x6c, 0x6f, 0x67, 0x67,
+	0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x06, 0x41, 0x64, 0x64, 0x41, 0x42, 0x49, 0x12, 0x15, 0x2e,
+	0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x41, 0x42, 0x49, 0x52, 0x65, 0x71, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x1a, 0x15, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x41, 0x42,
+	0x49, 0x52, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a,
+	0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x17, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72,
+	0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x1a, 0x18, 0x2e, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x42, 0x0b, 0x5a, 0x09,
+	0x2e, 0x2e, 0x2f, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -453,35 +2095,83 @@ func file_logger_proto_rawDescGZIP() []byte {
 	return file_logger_proto_rawDescData
 }
 
-var file_logger_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_logger_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_logger_proto_goTypes = []any{
-	(*Log)(nil),                // 0: logger.Log
-	(*InfoResMessage)(nil),     // 1: logger.InfoResMessage
-	(*ConnectReqMessage)(nil),  // 2: logger.ConnectReqMessage
-	(*BlockNumberMessage)(nil), // 3: logger.BlockNumberMessage
-	(*AddressReqMessage)(nil),  // 4: logger.AddressReqMessage
-	(*Log_Raw)(nil),            // 5: logger.Log.Raw
-	(*emptypb.Empty)(nil),      // 6: google.protobuf.Empty
+	(*Log)(nil),                   // 0: logger.Log
+	(*DecodedEvent)(nil),          // 1: logger.DecodedEvent
+	(*EventArg)(nil),              // 2: logger.EventArg
+	(*LogContext)(nil),            // 3: logger.LogContext
+	(*InfoResMessage)(nil),        // 4: logger.InfoResMessage
+	(*ChainInfo)(nil),             // 5: logger.ChainInfo
+	(*ChainReqMessage)(nil),       // 6: logger.ChainReqMessage
+	(*Topics)(nil),                // 7: logger.Topics
+	(*ConnectReqMessage)(nil),     // 8: logger.ConnectReqMessage
+	(*Cursor)(nil),                // 9: logger.Cursor
+	(*GetLogsReqMessage)(nil),     // 10: logger.GetLogsReqMessage
+	(*GetLogsResMessage)(nil),     // 11: logger.GetLogsResMessage
+	(*BlockNumberMessage)(nil),    // 12: logger.BlockNumberMessage
+	(*AddressReqMessage)(nil),     // 13: logger.AddressReqMessage
+	(*WatchedAddress)(nil),        // 14: logger.WatchedAddress
+	(*ListResMessage)(nil),        // 15: logger.ListResMessage
+	(*ABIReqMessage)(nil),         // 16: logger.ABIReqMessage
+	(*ABIResMessage)(nil),         // 17: logger.ABIResMessage
+	(*StatusResMessage)(nil),      // 18: logger.StatusResMessage
+	(*AddressStatus)(nil),         // 19: logger.AddressStatus
+	(*ClientStatus)(nil),          // 20: logger.ClientStatus
+	(*ErrorStatus)(nil),           // 21: logger.ErrorStatus
+	(*EndpointStatus)(nil),        // 22: logger.EndpointStatus
+	(*WebhookStatus)(nil),         // 23: logger.WebhookStatus
+	(*Log_Raw)(nil),               // 24: logger.Log.Raw
+	(*timestamppb.Timestamp)(nil), // 25: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 26: google.protobuf.Empty
 }
 var file_logger_proto_depIdxs = []int32{
-	5, // 0: logger.Log.raw:type_name -> logger.Log.Raw
-	6, // 1: logger.Logger.Info:input_type -> google.protobuf.Empty
-	2, // 2: logger.Logger.Connect:input_type -> logger.ConnectReqMessage
-	4, // 3: logger.Admin.Add:input_type -> logger.AddressReqMessage
-	4, // 4: logger.Admin.Remove:input_type -> logger.AddressReqMessage
-	3, // 5: logger.Admin.Start:input_type -> logger.BlockNumberMessage
-	6, // 6: logger.Admin.Stop:input_type -> google.protobuf.Empty
-	1, // 7: logger.Logger.Info:output_type -> logger.InfoResMessage
-	0, // 8: logger.Logger.Connect:output_type -> logger.Log
-	3, // 9: logger.Admin.Add:output_type -> logger.BlockNumberMessage
-	3, // 10: logger.Admin.Remove:output_type -> logger.BlockNumberMessage
-	6, // 11: logger.Admin.Start:output_type -> google.protobuf.Empty
-	3, // 12: logger.Admin.Stop:output_type -> logger.BlockNumberMessage
-	7, // [7:13] is the sub-list for method output_type
-	1, // [1:7] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	24, // 0: logger.Log.raw:type_name -> logger.Log.Raw
+	3,  // 1: logger.Log.context:type_name -> logger.LogContext
+	1,  // 2: logger.Log.event:type_name -> logger.DecodedEvent
+	2,  // 3: logger.DecodedEvent.args:type_name -> logger.EventArg
+	5,  // 4: logger.InfoResMessage.chains:type_name -> logger.ChainInfo
+	7,  // 5: logger.ConnectReqMessage.topics:type_name -> logger.Topics
+	9,  // 6: logger.ConnectReqMessage.resumeAfter:type_name -> logger.Cursor
+	7,  // 7: logger.GetLogsReqMessage.topics:type_name -> logger.Topics
+	0,  // 8: logger.GetLogsResMessage.logs:type_name -> logger.Log
+	25, // 9: logger.WatchedAddress.addedAt:type_name -> google.protobuf.Timestamp
+	14, // 10: logger.ListResMessage.addresses:type_name -> logger.WatchedAddress
+	19, // 11: logger.StatusResMessage.addresses:type_name -> logger.AddressStatus
+	20, // 12: logger.StatusResMessage.clients:type_name -> logger.ClientStatus
+	21, // 13: logger.StatusResMessage.errors:type_name -> logger.ErrorStatus
+	22, // 14: logger.StatusResMessage.endpoints:type_name -> logger.EndpointStatus
+	23, // 15: logger.StatusResMessage.webhooks:type_name -> logger.WebhookStatus
+	14, // 16: logger.AddressStatus.address:type_name -> logger.WatchedAddress
+	25, // 17: logger.ClientStatus.connectedAt:type_name -> google.protobuf.Timestamp
+	25, // 18: logger.ErrorStatus.time:type_name -> google.protobuf.Timestamp
+	25, // 19: logger.EndpointStatus.lastErrorAt:type_name -> google.protobuf.Timestamp
+	9,  // 20: logger.WebhookStatus.cursor:type_name -> logger.Cursor
+	26, // 21: logger.Logger.Info:input_type -> google.protobuf.Empty
+	8,  // 22: logger.Logger.Connect:input_type -> logger.ConnectReqMessage
+	10, // 23: logger.Logger.GetLogs:input_type -> logger.GetLogsReqMessage
+	13, // 24: logger.Admin.Add:input_type -> logger.AddressReqMessage
+	13, // 25: logger.Admin.Remove:input_type -> logger.AddressReqMessage
+	12, // 26: logger.Admin.Start:input_type -> logger.BlockNumberMessage
+	6,  // 27: logger.Admin.Stop:input_type -> logger.ChainReqMessage
+	6,  // 28: logger.Admin.List:input_type -> logger.ChainReqMessage
+	16, // 29: logger.Admin.AddABI:input_type -> logger.ABIReqMessage
+	6,  // 30: logger.Admin.Status:input_type -> logger.ChainReqMessage
+	4,  // 31: logger.Logger.Info:output_type -> logger.InfoResMessage
+	0,  // 32: logger.Logger.Connect:output_type -> logger.Log
+	11, // 33: logger.Logger.GetLogs:output_type -> logger.GetLogsResMessage
+	12, // 34: logger.Admin.Add:output_type -> logger.BlockNumberMessage
+	12, // 35: logger.Admin.Remove:output_type -> logger.BlockNumberMessage
+	26, // 36: logger.Admin.Start:output_type -> google.protobuf.Empty
+	12, // 37: logger.Admin.Stop:output_type -> logger.BlockNumberMessage
+	15, // 38: logger.Admin.List:output_type -> logger.ListResMessage
+	17, // 39: logger.Admin.AddABI:output_type -> logger.ABIResMessage
+	18, // 40: logger.Admin.Status:output_type -> logger.StatusResMessage
+	31, // [31:41] is the sub-list for method output_type
+	21, // [21:31] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_logger_proto_init() }
@@ -503,7 +2193,7 @@ func file_logger_proto_init() {
 			}
 		}
 		file_logger_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*InfoResMessage); i {
+			switch v := v.(*DecodedEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -515,7 +2205,7 @@ func file_logger_proto_init() {
 			}
 		}
 		file_logger_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*ConnectReqMessage); i {
+			switch v := v.(*EventArg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -527,7 +2217,7 @@ func file_logger_proto_init() {
 			}
 		}
 		file_logger_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*BlockNumberMessage); i {
+			switch v := v.(*LogContext); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -539,7 +2229,7 @@ func file_logger_proto_init() {
 			}
 		}
 		file_logger_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*AddressReqMessage); i {
+			switch v := v.(*InfoResMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -551,6 +2241,234 @@ func file_logger_proto_init() {
 			}
 		}
 		file_logger_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ChainInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*ChainReqMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*Topics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*ConnectReqMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*Cursor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*GetLogsReqMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*GetLogsResMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*BlockNumberMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*AddressReqMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*WatchedAddress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*ListResMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*ABIReqMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*ABIResMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*StatusResMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*AddressStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*ClientStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*ErrorStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*EndpointStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*WebhookStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_logger_proto_msgTypes[24].Exporter = func(v any, i int) any {
 			switch v := v.(*Log_Raw); i {
 			case 0:
 				return &v.state
@@ -569,7 +2487,7 @@ func file_logger_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_logger_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
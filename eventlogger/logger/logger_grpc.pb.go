@@ -22,6 +22,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	Logger_Info_FullMethodName    = "/logger.Logger/Info"
 	Logger_Connect_FullMethodName = "/logger.Logger/Connect"
+	Logger_GetLogs_FullMethodName = "/logger.Logger/GetLogs"
 )
 
 // LoggerClient is the client API for Logger service.
@@ -31,6 +32,7 @@ type LoggerClient interface {
 	// Public
 	Info(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*InfoResMessage, error)
 	Connect(ctx context.Context, in *ConnectReqMessage, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Log], error)
+	GetLogs(ctx context.Context, in *GetLogsReqMessage, opts ...grpc.CallOption) (*GetLogsResMessage, error)
 }
 
 type loggerClient struct {
@@ -70,6 +72,16 @@ func (c *loggerClient) Connect(ctx context.Context, in *ConnectReqMessage, opts
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Logger_ConnectClient = grpc.ServerStreamingClient[Log]
 
+func (c *loggerClient) GetLogs(ctx context.Context, in *GetLogsReqMessage, opts ...grpc.CallOption) (*GetLogsResMessage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLogsResMessage)
+	err := c.cc.Invoke(ctx, Logger_GetLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LoggerServer is the server API for Logger service.
 // All implementations must embed UnimplementedLoggerServer
 // for forward compatibility.
@@ -77,6 +89,7 @@ type LoggerServer interface {
 	// Public
 	Info(context.Context, *emptypb.Empty) (*InfoResMessage, error)
 	Connect(*ConnectReqMessage, grpc.ServerStreamingServer[Log]) error
+	GetLogs(context.Context, *GetLogsReqMessage) (*GetLogsResMessage, error)
 	mustEmbedUnimplementedLoggerServer()
 }
 
@@ -93,6 +106,9 @@ func (UnimplementedLoggerServer) Info(context.Context, *emptypb.Empty) (*InfoRes
 func (UnimplementedLoggerServer) Connect(*ConnectReqMessage, grpc.ServerStreamingServer[Log]) error {
 	return status.Errorf(codes.Unimplemented, "method Connect not implemented")
 }
+func (UnimplementedLoggerServer) GetLogs(context.Context, *GetLogsReqMessage) (*GetLogsResMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
+}
 func (UnimplementedLoggerServer) mustEmbedUnimplementedLoggerServer() {}
 func (UnimplementedLoggerServer) testEmbeddedByValue()                {}
 
@@ -143,6 +159,24 @@ func _Logger_Connect_Handler(srv interface{}, stream grpc.ServerStream) error {
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Logger_ConnectServer = grpc.ServerStreamingServer[Log]
 
+func _Logger_GetLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLogsReqMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LoggerServer).GetLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Logger_GetLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LoggerServer).GetLogs(ctx, req.(*GetLogsReqMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Logger_ServiceDesc is the grpc.ServiceDesc for Logger service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -154,6 +188,10 @@ var Logger_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Info",
 			Handler:    _Logger_Info_Handler,
 		},
+		{
+			MethodName: "GetLogs",
+			Handler:    _Logger_GetLogs_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -170,6 +208,9 @@ const (
 	Admin_Remove_FullMethodName = "/logger.Admin/Remove"
 	Admin_Start_FullMethodName  = "/logger.Admin/Start"
 	Admin_Stop_FullMethodName   = "/logger.Admin/Stop"
+	Admin_List_FullMethodName   = "/logger.Admin/List"
+	Admin_AddABI_FullMethodName = "/logger.Admin/AddABI"
+	Admin_Status_FullMethodName = "/logger.Admin/Status"
 )
 
 // AdminClient is the client API for Admin service.
@@ -180,7 +221,12 @@ type AdminClient interface {
 	Add(ctx context.Context, in *AddressReqMessage, opts ...grpc.CallOption) (*BlockNumberMessage, error)
 	Remove(ctx context.Context, in *AddressReqMessage, opts ...grpc.CallOption) (*BlockNumberMessage, error)
 	Start(ctx context.Context, in *BlockNumberMessage, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	Stop(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BlockNumberMessage, error)
+	Stop(ctx context.Context, in *ChainReqMessage, opts ...grpc.CallOption) (*BlockNumberMessage, error)
+	List(ctx context.Context, in *ChainReqMessage, opts ...grpc.CallOption) (*ListResMessage, error)
+	// 이벤트 디코딩에 사용할 ABI 를 등록한다. 등록된 ABI 는 모든 체인에서 사용되며, 재시작하면 설정 파일의 ABI 만 남는다.
+	AddABI(ctx context.Context, in *ABIReqMessage, opts ...grpc.CallOption) (*ABIResMessage, error)
+	// 로그 수집의 진행 상태와 연결된 클라이언트를 반환한다. 로그 수집을 멈추지 않는다.
+	Status(ctx context.Context, in *ChainReqMessage, opts ...grpc.CallOption) (*StatusResMessage, error)
 }
 
 type adminClient struct {
@@ -221,7 +267,7 @@ func (c *adminClient) Start(ctx context.Context, in *BlockNumberMessage, opts ..
 	return out, nil
 }
 
-func (c *adminClient) Stop(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BlockNumberMessage, error) {
+func (c *adminClient) Stop(ctx context.Context, in *ChainReqMessage, opts ...grpc.CallOption) (*BlockNumberMessage, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(BlockNumberMessage)
 	err := c.cc.Invoke(ctx, Admin_Stop_FullMethodName, in, out, cOpts...)
@@ -231,6 +277,36 @@ func (c *adminClient) Stop(ctx context.Context, in *emptypb.Empty, opts ...grpc.
 	return out, nil
 }
 
+func (c *adminClient) List(ctx context.Context, in *ChainReqMessage, opts ...grpc.CallOption) (*ListResMessage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResMessage)
+	err := c.cc.Invoke(ctx, Admin_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) AddABI(ctx context.Context, in *ABIReqMessage, opts ...grpc.CallOption) (*ABIResMessage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ABIResMessage)
+	err := c.cc.Invoke(ctx, Admin_AddABI_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) Status(ctx context.Context, in *ChainReqMessage, opts ...grpc.CallOption) (*StatusResMessage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StatusResMessage)
+	err := c.cc.Invoke(ctx, Admin_Status_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServer is the server API for Admin service.
 // All implementations must embed UnimplementedAdminServer
 // for forward compatibility.
@@ -239,7 +315,12 @@ type AdminServer interface {
 	Add(context.Context, *AddressReqMessage) (*BlockNumberMessage, error)
 	Remove(context.Context, *AddressReqMessage) (*BlockNumberMessage, error)
 	Start(context.Context, *BlockNumberMessage) (*emptypb.Empty, error)
-	Stop(context.Context, *emptypb.Empty) (*BlockNumberMessage, error)
+	Stop(context.Context, *ChainReqMessage) (*BlockNumberMessage, error)
+	List(context.Context, *ChainReqMessage) (*ListResMessage, error)
+	// 이벤트 디코딩에 사용할 ABI 를 등록한다. 등록된 ABI 는 모든 체인에서 사용되며, 재시작하면 설정 파일의 ABI 만 남는다.
+	AddABI(context.Context, *ABIReqMessage) (*ABIResMessage, error)
+	// 로그 수집의 진행 상태와 연결된 클라이언트를 반환한다. 로그 수집을 멈추지 않는다.
+	Status(context.Context, *ChainReqMessage) (*StatusResMessage, error)
 	mustEmbedUnimplementedAdminServer()
 }
 
@@ -259,9 +340,18 @@ func (UnimplementedAdminServer) Remove(context.Context, *AddressReqMessage) (*Bl
 func (UnimplementedAdminServer) Start(context.Context, *BlockNumberMessage) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Start not implemented")
 }
-func (UnimplementedAdminServer) Stop(context.Context, *emptypb.Empty) (*BlockNumberMessage, error) {
+func (UnimplementedAdminServer) Stop(context.Context, *ChainReqMessage) (*BlockNumberMessage, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
 }
+func (UnimplementedAdminServer) List(context.Context, *ChainReqMessage) (*ListResMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedAdminServer) AddABI(context.Context, *ABIReqMessage) (*ABIResMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddABI not implemented")
+}
+func (UnimplementedAdminServer) Status(context.Context, *ChainReqMessage) (*StatusResMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
 func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
 func (UnimplementedAdminServer) testEmbeddedByValue()               {}
 
@@ -338,7 +428,7 @@ func _Admin_Start_Handler(srv interface{}, ctx context.Context, dec func(interfa
 }
 
 func _Admin_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(ChainReqMessage)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -350,7 +440,61 @@ func _Admin_Stop_Handler(srv interface{}, ctx context.Context, dec func(interfac
 		FullMethod: Admin_Stop_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AdminServer).Stop(ctx, req.(*emptypb.Empty))
+		return srv.(AdminServer).Stop(ctx, req.(*ChainReqMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChainReqMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).List(ctx, req.(*ChainReqMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_AddABI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ABIReqMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).AddABI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_AddABI_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).AddABI(ctx, req.(*ABIReqMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChainReqMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_Status_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).Status(ctx, req.(*ChainReqMessage))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -378,6 +522,18 @@ var Admin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Stop",
 			Handler:    _Admin_Stop_Handler,
 		},
+		{
+			MethodName: "List",
+			Handler:    _Admin_List_Handler,
+		},
+		{
+			MethodName: "AddABI",
+			Handler:    _Admin_AddABI_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _Admin_Status_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "logger.proto",
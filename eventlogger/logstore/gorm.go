@@ -0,0 +1,340 @@
+package logstore
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logtypes"
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	gormCheckpointID = "scan"
+	gormBatchSize    = 500
+	maxTopics        = 4 // LOG0 ~ LOG4
+)
+
+// gormStore 는 gorm 으로 PostgreSQL, SQLite 에 로그를 저장한다.
+// 여러 체인이 같은 테이블을 사용할 수 있도록 모든 행에 chain_id 를 저장하고, 조회할때 chainID 의 행만 사용한다.
+type gormStore struct {
+	db      *gorm.DB
+	chainID uint64
+}
+
+type gormLog struct {
+	ID          uint64      `gorm:"primaryKey;autoIncrement"`
+	ChainID     uint64      `gorm:"not null;uniqueIndex:idx_event_logs_block_hash,priority:1;index:idx_event_logs_address,priority:1;index:idx_event_logs_block,priority:1"`
+	BlockNumber uint64      `gorm:"index:idx_event_logs_address,priority:3;index:idx_event_logs_block,priority:2"`
+	BlockHash   common.Hash `gorm:"uniqueIndex:idx_event_logs_block_hash,priority:2"`
+	LogIndex    uint        `gorm:"uniqueIndex:idx_event_logs_block_hash,priority:3;index:idx_event_logs_address,priority:4;index:idx_event_logs_block,priority:3"`
+	TxHash      common.Hash
+	TxIndex     uint
+	Address     common.Address `gorm:"index:idx_event_logs_address,priority:2"`
+	TopicCount  int
+	Topic0      []byte
+	Topic1      []byte
+	Topic2      []byte
+	Topic3      []byte
+	Data        []byte
+
+	// 보강된 정보, 보강되지 않은 로그는 BlockTimestamp 가 NULL 이다.
+	BlockTimestamp *uint64
+	TxFrom         []byte
+	TxTo           []byte
+	TxStatus       *uint64
+	GasUsed        *uint64
+}
+
+func (gormLog) TableName() string { return "event_logs" }
+
+type gormCheckpoint struct {
+	ID          string `gorm:"primaryKey"`
+	BlockNumber uint64
+	BlockHash   common.Hash
+	UpdatedAt   time.Time
+}
+
+func (gormCheckpoint) TableName() string { return "event_log_checkpoints" }
+
+type gormAddress struct {
+	ChainID    uint64         `gorm:"primaryKey;autoIncrement:false"`
+	Address    common.Address `gorm:"primaryKey"`
+	Label      string
+	AddedBlock uint64
+	AddedAt    time.Time `gorm:"index"`
+	Active     bool
+}
+
+func (gormAddress) TableName() string { return "event_log_addresses" }
+
+type gormCursor struct {
+	ChainID     uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Name        string `gorm:"primaryKey"`
+	BlockNumber uint64
+	LogIndex    uint
+	UpdatedAt   time.Time
+}
+
+func (gormCursor) TableName() string { return "event_log_cursors" }
+
+// NewGormStore 는 필요한 테이블을 생성한 뒤 chainID 의 저장소를 반환한다.
+func NewGormStore(db *gorm.DB, chainID uint64) (LogStore, error) {
+	if err := db.AutoMigrate(&gormLog{}, &gormCheckpoint{}, &gormAddress{}, &gormCursor{}); err != nil {
+		return nil, err
+	}
+	return &gormStore{db: db, chainID: chainID}, nil
+}
+
+func logToGorm(chainID uint64, log logtypes.Log) *gormLog {
+	record := &gormLog{
+		ChainID:     chainID,
+		BlockNumber: log.BlockNumber,
+		BlockHash:   log.BlockHash,
+		LogIndex:    log.Index,
+		TxHash:      log.TxHash,
+		TxIndex:     log.TxIndex,
+		Address:     log.Address,
+		TopicCount:  len(log.Topics),
+		Data:        log.Data,
+	}
+	for i, topic := range []*[]byte{&record.Topic0, &record.Topic1, &record.Topic2, &record.Topic3} {
+		if i < len(log.Topics) {
+			*topic = log.Topics[i].Bytes()
+		}
+	}
+	if c := log.Context; c != nil {
+		timestamp, status, gasUsed := c.BlockTimestamp, c.Status, c.GasUsed
+		record.BlockTimestamp, record.TxStatus, record.GasUsed = &timestamp, &status, &gasUsed
+		record.TxFrom = c.From.Bytes()
+		if c.To != nil {
+			record.TxTo = c.To.Bytes()
+		}
+	}
+	return record
+}
+
+func (record *gormLog) toLog() logtypes.Log {
+	topics := make([]common.Hash, 0, record.TopicCount)
+	for i, topic := range [][]byte{record.Topic0, record.Topic1, record.Topic2, record.Topic3} {
+		if i < record.TopicCount {
+			topics = append(topics, common.BytesToHash(topic))
+		}
+	}
+	log := logtypes.Log{}
+	log.BlockNumber = record.BlockNumber
+	log.BlockHash = record.BlockHash
+	log.Index = record.LogIndex
+	log.TxHash = record.TxHash
+	log.TxIndex = record.TxIndex
+	log.Address = record.Address
+	log.Topics = topics
+	log.Data = record.Data
+	if record.BlockTimestamp != nil {
+		log.Context = &logtypes.LogContext{
+			BlockTimestamp: *record.BlockTimestamp,
+			From:           common.BytesToAddress(record.TxFrom),
+		}
+		if len(record.TxTo) != 0 {
+			to := common.BytesToAddress(record.TxTo)
+			log.Context.To = &to
+		}
+		if record.TxStatus != nil {
+			log.Context.Status = *record.TxStatus
+		}
+		if record.GasUsed != nil {
+			log.Context.GasUsed = *record.GasUsed
+		}
+	}
+	return log
+}
+
+// insert 는 (blockHash, logIndex) 가 이미 저장된 로그를 무시한다.
+func (g *gormStore) insert(tx *gorm.DB, logs []logtypes.Log) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	records := make([]*gormLog, len(logs))
+	for i, log := range logs {
+		records[i] = logToGorm(g.chainID, log)
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(records, gormBatchSize).Error
+}
+
+func (g *gormStore) Commit(ctx context.Context, logs []logtypes.Log, checkpoint Checkpoint) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := g.insert(tx, logs); err != nil {
+			return err
+		}
+		return g.saveCheckpoint(tx, checkpoint)
+	})
+}
+
+func (g *gormStore) InsertLogs(ctx context.Context, logs []logtypes.Log) error {
+	return g.insert(g.db.WithContext(ctx), logs)
+}
+
+func (g *gormStore) QueryLogs(ctx context.Context, query Query) ([]logtypes.Log, error) {
+	tx := g.chain(ctx).Where("block_number >= ?", query.FromBlock)
+	if query.ToBlock != 0 {
+		tx = tx.Where("block_number <= ?", query.ToBlock)
+	}
+	if len(query.Addresses) != 0 {
+		tx = tx.Where("address IN ?", query.Addresses)
+	}
+	for i, position := range query.Topics {
+		if i >= maxTopics {
+			// 로그는 최대 4개의 토픽을 가진다.
+			return []logtypes.Log{}, nil
+		}
+		column := "topic" + strconv.Itoa(i)
+		if len(position) == 0 {
+			tx = tx.Where(column + " IS NOT NULL")
+		} else {
+			topics := make([][]byte, len(position))
+			for j, topic := range position {
+				topics[j] = topic.Bytes()
+			}
+			tx = tx.Where(column+" IN ?", topics)
+		}
+	}
+	if after := query.After; after != nil {
+		tx = tx.Where("block_number > ? OR (block_number = ? AND log_index > ?)", after.BlockNumber, after.BlockNumber, after.Index)
+	}
+	tx = tx.Order("block_number, log_index")
+	if query.Limit != 0 {
+		tx = tx.Limit(int(query.Limit))
+	}
+
+	records := []*gormLog{}
+	if err := tx.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	logs := make([]logtypes.Log, len(records))
+	for i, record := range records {
+		logs[i] = record.toLog()
+	}
+	return logs, nil
+}
+
+func (g *gormStore) CountLogs(ctx context.Context) (map[common.Address]uint64, error) {
+	records := []struct {
+		Address common.Address
+		Count   uint64
+	}{}
+	err := g.chain(ctx).Model(&gormLog{}).Select("address, COUNT(*) AS count").Group("address").Scan(&records).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[common.Address]uint64, len(records))
+	for _, record := range records {
+		counts[record.Address] = record.Count
+	}
+	return counts, nil
+}
+
+func (g *gormStore) LatestCheckpoint(ctx context.Context) (Checkpoint, error) {
+	records := []*gormCheckpoint{}
+	if err := g.db.WithContext(ctx).Where("id = ?", g.checkpointID()).Limit(1).Find(&records).Error; err != nil {
+		return Checkpoint{}, err
+	}
+	if len(records) == 0 {
+		return Checkpoint{}, nil
+	}
+	return Checkpoint{BlockNumber: records[0].BlockNumber, BlockHash: records[0].BlockHash}, nil
+}
+
+func (g *gormStore) DeleteRange(ctx context.Context, fromBlock uint64, checkpoint Checkpoint) error {
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("chain_id = ? AND block_number >= ?", g.chainID, fromBlock).Delete(&gormLog{}).Error; err != nil {
+			return err
+		}
+		return g.saveCheckpoint(tx, checkpoint)
+	})
+}
+
+func (g *gormStore) LoadAddresses(ctx context.Context) ([]WatchedAddress, error) {
+	records := []*gormAddress{}
+	if err := g.chain(ctx).Order("added_at").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	list := make([]WatchedAddress, len(records))
+	for i, record := range records {
+		list[i] = WatchedAddress{
+			Address:    record.Address,
+			Label:      record.Label,
+			AddedBlock: record.AddedBlock,
+			AddedAt:    record.AddedAt,
+			Active:     record.Active,
+		}
+	}
+	return list, nil
+}
+
+func (g *gormStore) SaveAddress(ctx context.Context, address WatchedAddress) error {
+	record := gormAddress{
+		ChainID:    g.chainID,
+		Address:    address.Address,
+		Label:      address.Label,
+		AddedBlock: address.AddedBlock,
+		AddedAt:    address.AddedAt,
+		Active:     address.Active,
+	}
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&record).Error
+}
+
+func (g *gormStore) LoadCursor(ctx context.Context, name string) (logtypes.Cursor, bool, error) {
+	records := []*gormCursor{}
+	if err := g.chain(ctx).Where("name = ?", name).Limit(1).Find(&records).Error; err != nil {
+		return logtypes.Cursor{}, false, err
+	}
+	if len(records) == 0 {
+		return logtypes.Cursor{}, false, nil
+	}
+	return logtypes.Cursor{BlockNumber: records[0].BlockNumber, Index: records[0].LogIndex}, true, nil
+}
+
+func (g *gormStore) SaveCursor(ctx context.Context, name string, cursor logtypes.Cursor) error {
+	record := gormCursor{
+		ChainID:     g.chainID,
+		Name:        name,
+		BlockNumber: cursor.BlockNumber,
+		LogIndex:    cursor.Index,
+		UpdatedAt:   time.Now(),
+	}
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&record).Error
+}
+
+func (g *gormStore) Close(ctx context.Context) error {
+	db, err := g.db.DB()
+	if err != nil {
+		return err
+	}
+	return db.Close()
+}
+
+func (g *gormStore) chain(ctx context.Context) *gorm.DB {
+	return g.db.WithContext(ctx).Where("chain_id = ?", g.chainID)
+}
+
+func (g *gormStore) checkpointID() string {
+	return gormCheckpointID + "-" + strconv.FormatUint(g.chainID, 10)
+}
+
+func (g *gormStore) saveCheckpoint(tx *gorm.DB, checkpoint Checkpoint) error {
+	return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&gormCheckpoint{
+		ID:          g.checkpointID(),
+		BlockNumber: checkpoint.BlockNumber,
+		BlockHash:   checkpoint.BlockHash,
+		UpdatedAt:   time.Now(),
+	}).Error
+}
+
+// 저장소 구현 확인
+var (
+	_ LogStore = (*gormStore)(nil)
+	_ LogStore = (*mongoStore)(nil)
+	_ LogStore = (*memoryStore)(nil)
+)
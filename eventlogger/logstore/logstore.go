@@ -0,0 +1,86 @@
+package logstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logtypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// LogStore 는 event-logger 가 수집한 로그와 수집 상태를 저장한다.
+type LogStore interface {
+	// Commit 은 로그들과 체크포인트를 함께 저장한다.
+	Commit(ctx context.Context, logs []logtypes.Log, checkpoint Checkpoint) error
+	// InsertLogs 는 아직 저장되지 않은 로그만 저장한다. (blockHash, logIndex) 가 같으면 이미 저장된 로그로 본다.
+	InsertLogs(ctx context.Context, logs []logtypes.Log) error
+	// QueryLogs 는 조건에 맞는 로그를 (block, logIndex) 순서로 반환한다.
+	QueryLogs(ctx context.Context, query Query) ([]logtypes.Log, error)
+	// CountLogs 는 주소 별로 저장된 로그 수를 반환한다. 저장된 로그가 없는 주소는 포함되지 않는다.
+	CountLogs(ctx context.Context) (map[common.Address]uint64, error)
+	// LatestCheckpoint 는 마지막으로 저장된 체크포인트를 반환한다. 저장된 적이 없다면 빈 값을 반환한다.
+	LatestCheckpoint(ctx context.Context) (Checkpoint, error)
+	// DeleteRange 는 재조직된 fromBlock 이후의 로그를 삭제하고, 체크포인트를 공통 조상으로 되돌린다.
+	DeleteRange(ctx context.Context, fromBlock uint64, checkpoint Checkpoint) error
+
+	// LoadAddresses 는 저장된 주소 목록을 추가된 순서로 반환한다.
+	LoadAddresses(ctx context.Context) ([]WatchedAddress, error)
+	// SaveAddress 는 주소의 정보를 저장한다. 이미 저장된 주소라면 덮어쓴다.
+	SaveAddress(ctx context.Context, address WatchedAddress) error
+
+	// LoadCursor 는 name 으로 저장된 전달 위치(webhook)를 반환한다. 저장된 적이 없다면 false 를 반환한다.
+	LoadCursor(ctx context.Context, name string) (logtypes.Cursor, bool, error)
+	// SaveCursor 는 name 의 전달 위치를 저장한다. 이미 저장된 위치는 덮어쓴다.
+	SaveCursor(ctx context.Context, name string, cursor logtypes.Cursor) error
+
+	Close(ctx context.Context) error
+}
+
+// Checkpoint 는 로그 수집이 완료된 마지막 블록이다.
+type Checkpoint struct {
+	BlockNumber uint64
+	BlockHash   common.Hash
+}
+
+// WatchedAddress 는 로그를 수집하는 주소와 그 정보이다. Remove 된 주소는 Active 가 false 로 남는다.
+type WatchedAddress struct {
+	Address    common.Address
+	Label      string
+	AddedBlock uint64 // 이 블록부터 로그를 수집한다.
+	AddedAt    time.Time
+	Active     bool
+}
+
+// Query 는 저장된 로그의 조회 조건이다.
+type Query struct {
+	Addresses []common.Address // 빈 목록은 모든 주소
+	Topics    [][]common.Hash  // logtypes.MatchTopics 와 같은 규칙
+	FromBlock uint64
+	ToBlock   uint64           // 0 이면 상한을 두지 않는다.
+	After     *logtypes.Cursor // 설정되면 해당 위치 이후의 로그만 조회한다.
+	Limit     int64            // 0 이면 제한하지 않는다.
+}
+
+// Match 는 저장소에서 조회 조건을 직접 사용할 수 없을 때 로그가 조건에 맞는지 확인한다.
+func (q Query) Match(log types.Log) bool {
+	if log.BlockNumber < q.FromBlock || (q.ToBlock != 0 && log.BlockNumber > q.ToBlock) {
+		return false
+	}
+	if q.After != nil && !q.After.Less(logtypes.CursorOf(log)) {
+		return false
+	}
+	if len(q.Addresses) != 0 {
+		found := false
+		for _, address := range q.Addresses {
+			if address == log.Address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return logtypes.MatchTopics(log, q.Topics)
+}
@@ -0,0 +1,219 @@
+package logstore_test
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logstore"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logtypes"
+	"github.com/bang9ming9/bm-cli-tool/testutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogStore(t *testing.T) {
+	t.Run("Memory", func(t *testing.T) {
+		testLogStore(t, logstore.NewMemoryStore())
+	})
+	t.Run("SQLite", func(t *testing.T) {
+		store, err := logstore.NewGormStore(testutils.NewSQLMock(t), 1)
+		require.NoError(t, err)
+		testLogStore(t, store)
+	})
+	t.Run("SQLite/Chains", func(t *testing.T) {
+		// 같은 테이블을 사용하는 체인들은 서로의 로그, 체크포인트, 주소 목록, 전달 위치를 보지 않는다.
+		ctx, db := context.Background(), testutils.NewSQLMock(t)
+		store1, err := logstore.NewGormStore(db, 1)
+		require.NoError(t, err)
+		store2, err := logstore.NewGormStore(db, 2)
+		require.NoError(t, err)
+
+		address := common.HexToAddress("0xa")
+		log := logtypes.Log{Log: types.Log{BlockNumber: 1, BlockHash: common.HexToHash("0x1"), Address: address, Topics: []common.Hash{{}}}}
+		require.NoError(t, store1.Commit(ctx, []logtypes.Log{log}, logstore.Checkpoint{BlockNumber: 1, BlockHash: log.BlockHash}))
+		require.NoError(t, store1.SaveAddress(ctx, logstore.WatchedAddress{Address: address, Active: true}))
+		// 같은 블록 해시의 로그도 체인이 다르면 따로 저장된다.
+		require.NoError(t, store2.InsertLogs(ctx, []logtypes.Log{log}))
+
+		logs, err := store2.QueryLogs(ctx, logstore.Query{})
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		require.NoError(t, store2.DeleteRange(ctx, 1, logstore.Checkpoint{}))
+		checkpoint, err := store2.LatestCheckpoint(ctx)
+		require.NoError(t, err)
+		require.Equal(t, logstore.Checkpoint{}, checkpoint)
+		list, err := store2.LoadAddresses(ctx)
+		require.NoError(t, err)
+		require.Empty(t, list)
+		require.NoError(t, store1.SaveCursor(ctx, "hook", logtypes.Cursor{BlockNumber: 1}))
+		_, ok, err := store2.LoadCursor(ctx, "hook")
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		logs, err = store1.QueryLogs(ctx, logstore.Query{})
+		require.NoError(t, err)
+		require.Equal(t, []logtypes.Log{log}, logs)
+		counts, err := store1.CountLogs(ctx)
+		require.NoError(t, err)
+		require.Equal(t, map[common.Address]uint64{address: 1}, counts)
+		checkpoint, err = store1.LatestCheckpoint(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), checkpoint.BlockNumber)
+	})
+}
+
+func testLogStore(t *testing.T, store logstore.LogStore) {
+	ctx := context.Background()
+	defer store.Close(ctx)
+
+	a, b := common.HexToAddress("0xa"), common.HexToAddress("0xb")
+	transfer, approval, holder := common.HexToHash("0x1"), common.HexToHash("0x2"), common.HexToHash("0x3")
+	makeLog := func(number uint64, index uint, address common.Address, topics ...common.Hash) logtypes.Log {
+		return logtypes.Log{Log: types.Log{
+			BlockNumber: number,
+			BlockHash:   common.BigToHash(new(big.Int).SetUint64(number)),
+			Index:       index,
+			TxHash:      common.HexToHash("0xff"),
+			Address:     address,
+			Topics:      topics,
+			Data:        []byte{byte(number), byte(index)},
+		}}
+	}
+	logs := []logtypes.Log{
+		makeLog(1, 0, a, transfer, holder),
+		makeLog(1, 1, b, approval),
+		makeLog(2, 0, a, approval, holder),
+		makeLog(3, 0, b, transfer),
+		makeLog(3, 1, a, transfer),
+	}
+	// 보강된 로그는 보강된 정보와 함께 저장된다. 컨트랙트 생성 트랜잭션은 To 가 없다.
+	logs[0].Context = &logtypes.LogContext{BlockTimestamp: 100, From: b, To: &a, Status: types.ReceiptStatusSuccessful, GasUsed: 21000}
+	logs[3].Context = &logtypes.LogContext{BlockTimestamp: 300, From: a, Status: types.ReceiptStatusSuccessful, GasUsed: 50000}
+
+	checkpoint, err := store.LatestCheckpoint(ctx)
+	require.NoError(t, err)
+	require.Equal(t, logstore.Checkpoint{}, checkpoint)
+
+	// 순서와 상관없이 저장되고, 중복된 로그는 무시된다.
+	require.NoError(t, store.Commit(ctx, logs[2:], logstore.Checkpoint{BlockNumber: 3, BlockHash: logs[4].BlockHash}))
+	require.NoError(t, store.InsertLogs(ctx, logs[:3]))
+	checkpoint, err = store.LatestCheckpoint(ctx)
+	require.NoError(t, err)
+	require.Equal(t, logstore.Checkpoint{BlockNumber: 3, BlockHash: logs[4].BlockHash}, checkpoint)
+
+	query := func(q logstore.Query, expected ...logtypes.Log) {
+		result, err := store.QueryLogs(ctx, q)
+		require.NoError(t, err)
+		require.Equal(t, len(expected), len(result))
+		for i, log := range expected {
+			require.Equal(t, log, result[i])
+		}
+	}
+	query(logstore.Query{}, logs...)
+	query(logstore.Query{FromBlock: 2, ToBlock: 2}, logs[2])
+	query(logstore.Query{Addresses: []common.Address{b}}, logs[1], logs[3])
+	query(logstore.Query{Topics: [][]common.Hash{{transfer}}}, logs[0], logs[3], logs[4])
+	query(logstore.Query{Topics: [][]common.Hash{nil, {holder}}}, logs[0], logs[2])
+	query(logstore.Query{Topics: [][]common.Hash{{transfer, approval}, nil}}, logs[0], logs[2])
+	query(logstore.Query{After: &logtypes.Cursor{BlockNumber: 1, Index: 1}, Limit: 2}, logs[2], logs[3])
+
+	counts, err := store.CountLogs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[common.Address]uint64{a: 3, b: 2}, counts)
+
+	// 재조직: 2번 블록 이후의 로그를 삭제한다.
+	require.NoError(t, store.DeleteRange(ctx, 2, logstore.Checkpoint{BlockNumber: 1, BlockHash: logs[0].BlockHash}))
+	query(logstore.Query{}, logs[:2]...)
+	checkpoint, err = store.LatestCheckpoint(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), checkpoint.BlockNumber)
+
+	// 주소 목록
+	added := time.Unix(time.Now().Unix(), 0).UTC()
+	require.NoError(t, store.SaveAddress(ctx, logstore.WatchedAddress{Address: a, Label: "a", AddedBlock: 1, AddedAt: added, Active: true}))
+	require.NoError(t, store.SaveAddress(ctx, logstore.WatchedAddress{Address: b, AddedBlock: 2, AddedAt: added.Add(time.Second), Active: true}))
+	require.NoError(t, store.SaveAddress(ctx, logstore.WatchedAddress{Address: a, Label: "a", AddedBlock: 1, AddedAt: added, Active: false}))
+	list, err := store.LoadAddresses(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, a, list[0].Address)
+	require.Equal(t, "a", list[0].Label)
+	require.False(t, list[0].Active)
+	require.True(t, added.Equal(list[0].AddedAt))
+	require.Equal(t, b, list[1].Address)
+	require.Equal(t, uint64(2), list[1].AddedBlock)
+	require.True(t, list[1].Active)
+
+	// 전달 위치
+	_, ok, err := store.LoadCursor(ctx, "hook")
+	require.NoError(t, err)
+	require.False(t, ok)
+	for _, expected := range []logtypes.Cursor{{BlockNumber: 3, Index: 1}, {BlockNumber: 5, Index: 0}} {
+		require.NoError(t, store.SaveCursor(ctx, "hook", expected))
+		cursor, ok, err := store.LoadCursor(ctx, "hook")
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expected, cursor)
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	ctx := context.Background()
+	source := logstore.NewMemoryStore()
+	a, b := common.HexToAddress("0xa"), common.HexToAddress("0xb")
+	logs := make([]logtypes.Log, 0, 2500)
+	for i := 0; i < cap(logs); i++ {
+		address := a
+		if i%2 == 1 {
+			address = b
+		}
+		logs = append(logs, logtypes.Log{Log: types.Log{
+			BlockNumber: uint64(i/10 + 1),
+			BlockHash:   common.BigToHash(big.NewInt(int64(i/10 + 1))),
+			Index:       uint(i % 10),
+			TxHash:      common.BigToHash(big.NewInt(int64(i))),
+			Address:     address,
+			Topics:      []common.Hash{common.HexToHash("0x1"), common.BigToHash(big.NewInt(int64(i)))},
+			Data:        []byte{byte(i)},
+		}})
+	}
+	logs[10].Context = &logtypes.LogContext{BlockTimestamp: 100, From: b, To: &a, Status: 1, GasUsed: 21000}
+	logs[12].Context = &logtypes.LogContext{BlockTimestamp: 100, From: b} // 컨트랙트 생성
+	require.NoError(t, source.InsertLogs(ctx, logs))
+
+	for _, format := range []logstore.Format{logstore.FormatJSONL, logstore.FormatCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			query := logstore.Query{Addresses: []common.Address{a}, FromBlock: 2, ToBlock: 200}
+			exported := new(bytes.Buffer)
+			count, err := logstore.Export(ctx, source, 1, query, format, exported)
+			require.NoError(t, err)
+			require.Equal(t, 995, count)
+
+			target, err := logstore.NewGormStore(testutils.NewSQLMock(t), 1)
+			require.NoError(t, err)
+			_, err = logstore.Import(ctx, target, 2, format, bytes.NewReader(exported.Bytes()))
+			require.ErrorContains(t, err, "chain id mismatch")
+			// 두번 가져와도 중복되어 저장되지 않는다.
+			for i := 0; i < 2; i++ {
+				count, err = logstore.Import(ctx, target, 1, format, bytes.NewReader(exported.Bytes()))
+				require.NoError(t, err)
+				require.Equal(t, 995, count)
+			}
+
+			expected, err := source.QueryLogs(ctx, query)
+			require.NoError(t, err)
+			imported, err := target.QueryLogs(ctx, logstore.Query{})
+			require.NoError(t, err)
+			require.Equal(t, expected, imported)
+
+			reexported := new(bytes.Buffer)
+			_, err = logstore.Export(ctx, target, 1, logstore.Query{}, format, reexported)
+			require.NoError(t, err)
+			require.Equal(t, exported.String(), reexported.String())
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package logstore
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logtypes"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// memoryStore 는 프로세스 메모리에 로그를 저장한다. 테스트나 재시작 후 다시 수집해도 되는 작은 배포에서 사용한다.
+type memoryStore struct {
+	lock       sync.RWMutex
+	logs       []logtypes.Log // (block, logIndex) 순서
+	keys       map[logKey]struct{}
+	checkpoint Checkpoint
+	addresses  []WatchedAddress
+	cursors    map[string]logtypes.Cursor
+}
+
+type logKey struct {
+	hash  common.Hash
+	index uint
+}
+
+func NewMemoryStore() LogStore {
+	return &memoryStore{keys: make(map[logKey]struct{}), cursors: make(map[string]logtypes.Cursor)}
+}
+
+func (m *memoryStore) Commit(ctx context.Context, logs []logtypes.Log, checkpoint Checkpoint) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.insert(logs)
+	m.checkpoint = checkpoint
+	return nil
+}
+
+func (m *memoryStore) InsertLogs(ctx context.Context, logs []logtypes.Log) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.insert(logs)
+	return nil
+}
+
+func (m *memoryStore) insert(logs []logtypes.Log) {
+	inserted := false
+	for _, log := range logs {
+		key := logKey{log.BlockHash, log.Index}
+		if _, ok := m.keys[key]; ok {
+			continue
+		}
+		m.keys[key] = struct{}{}
+		m.logs = append(m.logs, log)
+		inserted = true
+	}
+	if inserted {
+		sort.SliceStable(m.logs, func(i, j int) bool {
+			return logtypes.CursorOf(m.logs[i].Log).Less(logtypes.CursorOf(m.logs[j].Log))
+		})
+	}
+}
+
+func (m *memoryStore) QueryLogs(ctx context.Context, query Query) ([]logtypes.Log, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	logs := []logtypes.Log{}
+	for _, log := range m.logs {
+		if query.Limit != 0 && int64(len(logs)) >= query.Limit {
+			break
+		}
+		if query.Match(log.Log) {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+func (m *memoryStore) CountLogs(ctx context.Context) (map[common.Address]uint64, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	counts := make(map[common.Address]uint64)
+	for _, log := range m.logs {
+		counts[log.Address]++
+	}
+	return counts, nil
+}
+
+func (m *memoryStore) LatestCheckpoint(ctx context.Context) (Checkpoint, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.checkpoint, nil
+}
+
+func (m *memoryStore) DeleteRange(ctx context.Context, fromBlock uint64, checkpoint Checkpoint) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	logs := m.logs[:0]
+	for _, log := range m.logs {
+		if log.BlockNumber < fromBlock {
+			logs = append(logs, log)
+		} else {
+			delete(m.keys, logKey{log.BlockHash, log.Index})
+		}
+	}
+	m.logs, m.checkpoint = logs, checkpoint
+	return nil
+}
+
+func (m *memoryStore) LoadAddresses(ctx context.Context) ([]WatchedAddress, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return append([]WatchedAddress{}, m.addresses...), nil
+}
+
+func (m *memoryStore) SaveAddress(ctx context.Context, address WatchedAddress) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for i, w := range m.addresses {
+		if w.Address == address.Address {
+			m.addresses[i] = address
+			return nil
+		}
+	}
+	m.addresses = append(m.addresses, address)
+	return nil
+}
+
+func (m *memoryStore) LoadCursor(ctx context.Context, name string) (logtypes.Cursor, bool, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	cursor, ok := m.cursors[name]
+	return cursor, ok, nil
+}
+
+func (m *memoryStore) SaveCursor(ctx context.Context, name string, cursor logtypes.Cursor) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.cursors[name] = cursor
+	return nil
+}
+
+func (m *memoryStore) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,496 @@
+package logstore
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logtypes"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	checkpointSuffix = "_checkpoint"
+	checkpointID     = "scan"
+	addressesSuffix  = "_addresses"
+	cursorsSuffix    = "_cursors"
+)
+
+// mongoStore 는 로그를 collection 에, 체크포인트와 주소 목록, 전달 위치를 <collection>_checkpoint, <collection>_addresses,
+// <collection>_cursors 에 저장한다.
+// 여러 체인이 같은 컬렉션을 사용할 수 있도록 모든 문서에 chain_id 를 저장하고, 조회할때 chainID 의 문서만 사용한다.
+type mongoStore struct {
+	chainID       uint64
+	collection    *mongo.Collection
+	checkpoints   *mongo.Collection
+	addresses     *mongo.Collection
+	cursors       *mongo.Collection
+	noTransaction bool
+}
+
+type mongoCheckpoint struct {
+	ID          string      `bson:"_id"`
+	BlockNumber int64       `bson:"block_number"`
+	BlockHash   common.Hash `bson:"block_hash"`
+	UpdatedAt   time.Time   `bson:"updated_at"`
+}
+
+type mongoAddress struct {
+	ID         string         `bson:"_id"`
+	ChainID    int64          `bson:"chain_id"`
+	Address    common.Address `bson:"address"`
+	Label      string         `bson:"label"`
+	AddedBlock int64          `bson:"added_block"`
+	AddedAt    time.Time      `bson:"added_at"`
+	Active     bool           `bson:"active"`
+}
+
+type mongoCursor struct {
+	ID          string    `bson:"_id"`
+	ChainID     int64     `bson:"chain_id"`
+	Name        string    `bson:"name"`
+	BlockNumber int64     `bson:"block_number"`
+	Index       int64     `bson:"index"`
+	UpdatedAt   time.Time `bson:"updated_at"`
+}
+
+const uniqueIndexName = "chain_block_hash_index"
+
+// NewMongoStore 는 이전 버전의 문서를 변환하고 인덱스를 생성한 뒤 chainID 의 저장소를 반환한다.
+// chain_id 가 없는 이전 버전의 문서는 처음으로 열린 체인의 문서로 변환된다.
+func NewMongoStore(ctx context.Context, collection *mongo.Collection, chainID uint64) (LogStore, error) {
+	database := collection.Database()
+	m := &mongoStore{
+		chainID:     chainID,
+		collection:  collection,
+		checkpoints: database.Collection(collection.Name() + checkpointSuffix),
+		addresses:   database.Collection(collection.Name() + addressesSuffix),
+		cursors:     database.Collection(collection.Name() + cursorsSuffix),
+	}
+	if err := m.migrate(ctx); err != nil {
+		return nil, err
+	}
+	if err := m.ensureIndexes(ctx); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// migrate 는 이전 버전의 문서를 변환한다.
+//   - SplitUint64 로 나누어 저장된 블록 번호를 raw.block_number 로 합친다.
+//   - chain_id 가 없는 로그, 체크포인트, 주소에 m.chainID 를 저장한다.
+func (m *mongoStore) migrate(ctx context.Context) error {
+	untagged := bson.D{{Key: "chain_id", Value: bson.D{{Key: "$exists", Value: false}}}}
+	if _, err := m.collection.UpdateMany(ctx, untagged, bson.D{{Key: "$set", Value: m.chainFilter()}}); err != nil {
+		return err
+	}
+
+	legacy := mongoCheckpoint{}
+	if err := m.checkpoints.FindOne(ctx, bson.D{{Key: "_id", Value: checkpointID}}).Decode(&legacy); err == nil {
+		legacy.ID = m.checkpointID()
+		if _, err := m.checkpoints.InsertOne(ctx, legacy); err != nil && !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+		if _, err := m.checkpoints.DeleteOne(ctx, bson.D{{Key: "_id", Value: checkpointID}}); err != nil {
+			return err
+		}
+	} else if !isNoDocuments(err) {
+		return err
+	}
+
+	cursor, err := m.addresses.Find(ctx, untagged)
+	if err != nil {
+		return err
+	}
+	legacyAddresses := []struct {
+		Address    common.Address `bson:"_id"`
+		Label      string         `bson:"label"`
+		AddedBlock int64          `bson:"added_block"`
+		AddedAt    time.Time      `bson:"added_at"`
+		Active     bool           `bson:"active"`
+	}{}
+	if err := cursor.All(ctx, &legacyAddresses); err != nil {
+		return err
+	}
+	for _, legacy := range legacyAddresses {
+		err := m.saveAddress(ctx, mongoAddress{
+			Address:    legacy.Address,
+			Label:      legacy.Label,
+			AddedBlock: legacy.AddedBlock,
+			AddedAt:    legacy.AddedAt,
+			Active:     legacy.Active,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := m.addresses.DeleteOne(ctx, bson.D{{Key: "_id", Value: legacy.Address}}); err != nil {
+			return err
+		}
+	}
+
+	filter := bson.D{
+		{Key: "raw.block_number", Value: bson.D{{Key: "$exists", Value: false}}},
+		{Key: "raw.block_number_high", Value: bson.D{{Key: "$exists", Value: true}}},
+	}
+	_, err = m.collection.UpdateMany(ctx, filter, mongo.Pipeline{
+		{{Key: "$set", Value: bson.D{{Key: "raw.block_number", Value: bson.D{{Key: "$add", Value: bson.A{
+			bson.D{{Key: "$multiply", Value: bson.A{bson.D{{Key: "$toDecimal", Value: "$raw.block_number_high"}}, int64(1 << 32)}}},
+			bson.D{{Key: "$toDecimal", Value: "$raw.block_number_low"}},
+		}}}}}}},
+		{{Key: "$unset", Value: bson.A{"raw.block_number_high", "raw.block_number_low"}}},
+	})
+	return err
+}
+
+// ensureIndexes 는 조회에 사용하는 인덱스와 (blockHash, logIndex) 유니크 인덱스를 생성한다.
+// 유니크 인덱스가 없던 이전 버전에서 중복 저장된 문서는 인덱스를 만들기 전에 하나만 남기고 삭제한다.
+func (m *mongoStore) ensureIndexes(ctx context.Context) error {
+	names, err := m.collection.Indexes().ListSpecifications(ctx)
+	if err != nil {
+		return err
+	}
+	unique := false
+	for _, spec := range names {
+		unique = unique || spec.Name == uniqueIndexName
+	}
+	if !unique {
+		if err := m.removeDuplicates(ctx); err != nil {
+			return err
+		}
+	}
+	_, err = m.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "chain_id", Value: 1}, {Key: "address", Value: 1}, {Key: "raw.block_number", Value: 1}, {Key: "raw.index", Value: 1}},
+			Options: options.Index().SetName("chain_address_block_index"),
+		},
+		{
+			Keys:    bson.D{{Key: "chain_id", Value: 1}, {Key: "raw.block_number", Value: 1}, {Key: "raw.index", Value: 1}},
+			Options: options.Index().SetName("chain_block_index"),
+		},
+		{
+			Keys:    bson.D{{Key: "chain_id", Value: 1}, {Key: "raw.block_hash", Value: 1}, {Key: "raw.index", Value: 1}},
+			Options: options.Index().SetName(uniqueIndexName).SetUnique(true),
+		},
+	})
+	return err
+}
+
+func (m *mongoStore) removeDuplicates(ctx context.Context) error {
+	cursor, err := m.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "chain", Value: "$chain_id"}, {Key: "hash", Value: "$raw.block_hash"}, {Key: "index", Value: "$raw.index"}}},
+			{Key: "ids", Value: bson.D{{Key: "$push", Value: "$_id"}}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+		{{Key: "$match", Value: bson.D{{Key: "count", Value: bson.D{{Key: "$gt", Value: 1}}}}}},
+	}, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		duplicated := struct {
+			IDs []interface{} `bson:"ids"`
+		}{}
+		if err := cursor.Decode(&duplicated); err != nil {
+			return err
+		}
+		if _, err := m.collection.DeleteMany(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: duplicated.IDs[1:]}}}}); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// Commit 은 트랜잭션 안에서 유니크 인덱스 에러가 발생하지 않도록 이미 저장된 로그를 제외하고 저장한다.
+func (m *mongoStore) Commit(ctx context.Context, logs []logtypes.Log, checkpoint Checkpoint) error {
+	return m.transact(ctx, func(ctx context.Context) error {
+		missing, err := m.missing(ctx, logs)
+		if err != nil {
+			return err
+		}
+		if len(missing) != 0 {
+			if _, err := m.collection.InsertMany(ctx, m.documents(missing)); err != nil {
+				return err
+			}
+		}
+		return m.saveCheckpoint(ctx, checkpoint)
+	})
+}
+
+func (m *mongoStore) InsertLogs(ctx context.Context, logs []logtypes.Log) error {
+	missing, err := m.missing(ctx, logs)
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+	// 조회 이후에 다른 곳에서 저장된 로그는 유니크 인덱스로 걸러진다.
+	_, err = m.collection.InsertMany(ctx, m.documents(missing), options.InsertMany().SetOrdered(false))
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+// missing 은 logs 중 아직 저장되지 않은 로그를 반환한다.
+func (m *mongoStore) missing(ctx context.Context, logs []logtypes.Log) ([]logtypes.Log, error) {
+	if len(logs) == 0 {
+		return nil, nil
+	}
+	hashes := make([]common.Hash, 0, len(logs))
+	for i, log := range logs {
+		if i == 0 || logs[i-1].BlockHash != log.BlockHash {
+			hashes = append(hashes, log.BlockHash)
+		}
+	}
+	filter := append(m.chainFilter(), bson.E{Key: "raw.block_hash", Value: bson.D{{Key: "$in", Value: hashes}}})
+	cursor, err := m.collection.Find(ctx, filter,
+		options.Find().SetProjection(bson.D{{Key: "raw.block_hash", Value: 1}, {Key: "raw.index", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	results := []bson.M{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	stored := make(map[logKey]struct{}, len(results))
+	for _, result := range results {
+		log := logtypes.LogFromBsonM(result)
+		stored[logKey{log.BlockHash, log.Index}] = struct{}{}
+	}
+	missing := make([]logtypes.Log, 0, len(logs))
+	for _, log := range logs {
+		if _, ok := stored[logKey{log.BlockHash, log.Index}]; !ok {
+			missing = append(missing, log)
+		}
+	}
+	return missing, nil
+}
+
+func (m *mongoStore) QueryLogs(ctx context.Context, query Query) ([]logtypes.Log, error) {
+	conditions := bson.A{m.chainFilter(), logtypes.BlockRangeToBson(query.FromBlock, query.ToBlock)}
+	if len(query.Addresses) != 0 {
+		conditions = append(conditions, bson.D{{Key: "address", Value: bson.D{{Key: "$in", Value: query.Addresses}}}})
+	}
+	if topics := logtypes.TopicsToBson(query.Topics); len(topics) != 0 {
+		conditions = append(conditions, topics)
+	}
+	if query.After != nil {
+		conditions = append(conditions, logtypes.AfterCursorToBson(*query.After))
+	}
+	findOptions := options.Find().SetSort(logtypes.SortToBson(1))
+	if query.Limit != 0 {
+		findOptions.SetLimit(query.Limit)
+	}
+	cursor, err := m.collection.Find(ctx, bson.D{{Key: "$and", Value: conditions}}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	results := []bson.M{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	logs := make([]logtypes.Log, len(results))
+	for i, result := range results {
+		logs[i].Log = logtypes.LogFromBsonM(result)
+		if context, ok := result["context"].(bson.M); ok {
+			logs[i].Context = logtypes.ContextFromBsonM(context)
+		}
+	}
+	return logs, nil
+}
+
+func (m *mongoStore) CountLogs(ctx context.Context) (map[common.Address]uint64, error) {
+	cursor, err := m.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: m.chainFilter()}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$address"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	results := []struct {
+		Address primitive.Binary `bson:"_id"`
+		Count   int64            `bson:"count"`
+	}{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	counts := make(map[common.Address]uint64, len(results))
+	for _, result := range results {
+		counts[common.BytesToAddress(result.Address.Data)] = uint64(result.Count)
+	}
+	return counts, nil
+}
+
+// LatestCheckpoint 는 체크포인트가 없다면 이전 버전과의 호환을 위해 마지막으로 저장된 로그의 블록 번호를 사용한다.
+func (m *mongoStore) LatestCheckpoint(ctx context.Context) (Checkpoint, error) {
+	cp := mongoCheckpoint{}
+	err := m.checkpoints.FindOne(ctx, bson.D{{Key: "_id", Value: m.checkpointID()}}).Decode(&cp)
+	if err == nil {
+		return Checkpoint{BlockNumber: uint64(cp.BlockNumber), BlockHash: cp.BlockHash}, nil
+	} else if !isNoDocuments(err) {
+		return Checkpoint{}, err
+	}
+
+	result := bson.M{}
+	err = m.collection.FindOne(ctx, m.chainFilter(), options.FindOne().SetSort(logtypes.SortToBson(-1))).Decode(&result)
+	if err != nil {
+		if isNoDocuments(err) {
+			return Checkpoint{}, nil
+		}
+		return Checkpoint{}, err
+	}
+	return Checkpoint{BlockNumber: logtypes.LogFromBsonM(result).BlockNumber}, nil
+}
+
+func (m *mongoStore) DeleteRange(ctx context.Context, fromBlock uint64, checkpoint Checkpoint) error {
+	return m.transact(ctx, func(ctx context.Context) error {
+		filter := bson.D{{Key: "$and", Value: bson.A{m.chainFilter(), logtypes.BlockRangeToBson(fromBlock, 0)}}}
+		if _, err := m.collection.DeleteMany(ctx, filter); err != nil {
+			return err
+		}
+		return m.saveCheckpoint(ctx, checkpoint)
+	})
+}
+
+func (m *mongoStore) LoadAddresses(ctx context.Context) ([]WatchedAddress, error) {
+	cursor, err := m.addresses.Find(ctx, m.chainFilter(), options.Find().SetSort(bson.D{{Key: "added_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	results := []mongoAddress{}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	list := make([]WatchedAddress, len(results))
+	for i, result := range results {
+		list[i] = WatchedAddress{
+			Address:    result.Address,
+			Label:      result.Label,
+			AddedBlock: uint64(result.AddedBlock),
+			AddedAt:    result.AddedAt,
+			Active:     result.Active,
+		}
+	}
+	return list, nil
+}
+
+func (m *mongoStore) SaveAddress(ctx context.Context, address WatchedAddress) error {
+	return m.saveAddress(ctx, mongoAddress{
+		Address:    address.Address,
+		Label:      address.Label,
+		AddedBlock: int64(address.AddedBlock),
+		AddedAt:    address.AddedAt,
+		Active:     address.Active,
+	})
+}
+
+// saveAddress 는 같은 주소를 여러 체인에서 사용할 수 있도록 "<chainID>-<address>" 를 _id 로 사용한다.
+func (m *mongoStore) saveAddress(ctx context.Context, address mongoAddress) error {
+	address.ID = strconv.FormatUint(m.chainID, 10) + "-" + address.Address.Hex()
+	address.ChainID = int64(m.chainID)
+	_, err := m.addresses.ReplaceOne(ctx, bson.D{{Key: "_id", Value: address.ID}}, address, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (m *mongoStore) LoadCursor(ctx context.Context, name string) (logtypes.Cursor, bool, error) {
+	result := mongoCursor{}
+	err := m.cursors.FindOne(ctx, bson.D{{Key: "_id", Value: m.cursorID(name)}}).Decode(&result)
+	if isNoDocuments(err) {
+		return logtypes.Cursor{}, false, nil
+	} else if err != nil {
+		return logtypes.Cursor{}, false, err
+	}
+	return logtypes.Cursor{BlockNumber: uint64(result.BlockNumber), Index: uint(result.Index)}, true, nil
+}
+
+func (m *mongoStore) SaveCursor(ctx context.Context, name string, cursor logtypes.Cursor) error {
+	id := m.cursorID(name)
+	_, err := m.cursors.ReplaceOne(ctx, bson.D{{Key: "_id", Value: id}}, mongoCursor{
+		ID:          id,
+		ChainID:     int64(m.chainID),
+		Name:        name,
+		BlockNumber: int64(cursor.BlockNumber),
+		Index:       int64(cursor.Index),
+		UpdatedAt:   time.Now(),
+	}, options.Replace().SetUpsert(true))
+	return err
+}
+
+// cursorID 는 같은 이름을 여러 체인에서 사용할 수 있도록 "<chainID>-<name>" 을 사용한다.
+func (m *mongoStore) cursorID(name string) string {
+	return strconv.FormatUint(m.chainID, 10) + "-" + name
+}
+
+func (m *mongoStore) Close(ctx context.Context) error {
+	return m.collection.Database().Client().Disconnect(ctx)
+}
+
+func (m *mongoStore) saveCheckpoint(ctx context.Context, checkpoint Checkpoint) error {
+	_, err := m.checkpoints.ReplaceOne(ctx, bson.D{{Key: "_id", Value: m.checkpointID()}}, mongoCheckpoint{
+		ID:          m.checkpointID(),
+		BlockNumber: int64(checkpoint.BlockNumber),
+		BlockHash:   checkpoint.BlockHash,
+		UpdatedAt:   time.Now(),
+	}, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (m *mongoStore) checkpointID() string {
+	return checkpointID + "-" + strconv.FormatUint(m.chainID, 10)
+}
+
+func (m *mongoStore) chainFilter() bson.D {
+	return bson.D{{Key: "chain_id", Value: int64(m.chainID)}}
+}
+
+// documents 는 로그를 chain_id 와 보강된 정보(context)가 포함된 문서로 변환한다.
+func (m *mongoStore) documents(logs []logtypes.Log) []interface{} {
+	documents := make([]interface{}, len(logs))
+	for i, log := range logs {
+		document := append(logtypes.LogToBson(log.Log), bson.E{Key: "chain_id", Value: int64(m.chainID)})
+		if log.Context != nil {
+			document = append(document, bson.E{Key: "context", Value: logtypes.ContextToBson(log.Context)})
+		}
+		documents[i] = document
+	}
+	return documents
+}
+
+// transact 는 fn 을 하나의 트랜잭션으로 실행한다.
+// 트랜잭션을 지원하지 않는 단일 노드 MongoDB 에서는 트랜잭션 없이 순서대로 실행한다.
+func (m *mongoStore) transact(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !m.noTransaction {
+		session, err := m.collection.Database().Client().StartSession()
+		if err != nil {
+			return err
+		}
+		defer session.EndSession(ctx)
+
+		_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+			return nil, fn(sc)
+		})
+		if !isTransactionUnsupported(err) {
+			return err
+		}
+		m.noTransaction = true
+	}
+	return fn(ctx)
+}
+
+func isTransactionUnsupported(err error) bool {
+	var cmdErr mongo.CommandError
+	// IllegalOperation: Transaction numbers are only allowed on a replica set member or mongos
+	return errors.As(err, &cmdErr) && cmdErr.HasErrorCode(20)
+}
+
+func isNoDocuments(err error) bool {
+	return errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, mongo.ErrNilDocument)
+}
@@ -0,0 +1,158 @@
+package logstore
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logtypes"
+)
+
+// Format 은 Export, Import 파일의 형식이다.
+type Format string
+
+const (
+	FormatJSONL Format = "jsonl" // 한 줄에 하나의 logtypes.LogJSON
+	FormatCSV   Format = "csv"   // logtypes.CSVHeader 를 첫 줄로 하는 csv
+)
+
+const transferBatchSize = 1000
+
+func ParseFormat(format string) (Format, error) {
+	switch Format(format) {
+	case FormatJSONL, FormatCSV:
+		return Format(format), nil
+	default:
+		return "", errors.New("invalid format: " + format)
+	}
+}
+
+// Export 는 조건에 맞는 로그를 (block, logIndex) 순서로 w 에 쓰고, 쓴 로그의 수를 반환한다.
+// 저장소는 체인 ID 를 알지 못하기 때문에 chainID 를 함께 기록한다. query.After 와 query.Limit 은 무시된다.
+func Export(ctx context.Context, store LogStore, chainID uint64, query Query, format Format, w io.Writer) (int, error) {
+	var write func(log logtypes.Log) error
+	flush := func() error { return nil }
+	switch format {
+	case FormatJSONL:
+		encoder := json.NewEncoder(w)
+		write = func(log logtypes.Log) error {
+			return encoder.Encode(logtypes.LogToJSON(chainID, log))
+		}
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(logtypes.CSVHeader); err != nil {
+			return 0, err
+		}
+		write = func(log logtypes.Log) error {
+			return writer.Write(logtypes.LogToCSV(chainID, log))
+		}
+		flush = func() error {
+			writer.Flush()
+			return writer.Error()
+		}
+	default:
+		return 0, errors.New("invalid format: " + string(format))
+	}
+
+	count := 0
+	query.After, query.Limit = nil, transferBatchSize
+	for {
+		logs, err := store.QueryLogs(ctx, query)
+		if err != nil {
+			return count, err
+		}
+		for _, log := range logs {
+			if err := write(log); err != nil {
+				return count, err
+			}
+		}
+		count += len(logs)
+		if len(logs) < transferBatchSize {
+			break
+		}
+		after := logtypes.CursorOf(logs[len(logs)-1].Log)
+		query.After = &after
+	}
+	return count, flush()
+}
+
+// Import 는 Export 로 만든 파일의 로그를 저장하고, 읽은 로그의 수를 반환한다.
+// 이미 저장된 로그는 다시 저장하지 않으며 (LogStore.InsertLogs), 체크포인트는 바꾸지 않는다.
+// 다른 체인의 로그가 있으면 저장을 멈추고 에러를 반환한다.
+func Import(ctx context.Context, store LogStore, chainID uint64, format Format, r io.Reader) (int, error) {
+	var read func() (uint64, logtypes.Log, error)
+	line := 0
+	switch format {
+	case FormatJSONL:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		read = func() (uint64, logtypes.Log, error) {
+			for scanner.Scan() {
+				line++
+				if len(scanner.Bytes()) == 0 {
+					continue
+				}
+				record := logtypes.LogJSON{}
+				if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+					return 0, logtypes.Log{}, err
+				}
+				id, log := logtypes.LogFromJSON(record)
+				return id, log, nil
+			}
+			if err := scanner.Err(); err != nil {
+				return 0, logtypes.Log{}, err
+			}
+			return 0, logtypes.Log{}, io.EOF
+		}
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = len(logtypes.CSVHeader)
+		if _, err := reader.Read(); err != nil {
+			return 0, fmt.Errorf("invalid csv header: %w", err)
+		}
+		line++
+		read = func() (uint64, logtypes.Log, error) {
+			record, err := reader.Read()
+			if err != nil {
+				return 0, logtypes.Log{}, err
+			}
+			line++
+			return logtypes.LogFromCSV(record)
+		}
+	default:
+		return 0, errors.New("invalid format: " + string(format))
+	}
+
+	count, batch := 0, make([]logtypes.Log, 0, transferBatchSize)
+	insert := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := store.InsertLogs(ctx, batch); err != nil {
+			return err
+		}
+		count, batch = count+len(batch), batch[:0]
+		return nil
+	}
+	for {
+		id, log, err := read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return count, fmt.Errorf("line %d: %w", line, err)
+		}
+		if id != chainID {
+			return count, fmt.Errorf("line %d: chain id mismatch: file %d, store %d", line, id, chainID)
+		}
+		if batch = append(batch, log); len(batch) == transferBatchSize {
+			if err := insert(); err != nil {
+				return count, err
+			}
+		}
+	}
+	return count, insert()
+}
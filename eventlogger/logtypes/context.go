@@ -0,0 +1,102 @@
+package logtypes
+
+import (
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Log 는 event-logger 가 저장하고 전달하는 로그이다.
+// Context 는 로그 보강이 설정되지 않았거나 보강에 실패한 로그에서 nil 이다.
+type Log struct {
+	types.Log
+	Context *LogContext
+}
+
+// LogContext 는 로그가 포함된 블록과 트랜잭션의 정보이다.
+type LogContext struct {
+	BlockTimestamp uint64
+	From           common.Address
+	To             *common.Address // 컨트랙트 생성 트랜잭션은 nil
+	Status         uint64
+	GasUsed        uint64
+}
+
+// WithoutContext 는 보강되지 않은 로그 목록을 만든다.
+func WithoutContext(logs []types.Log) []Log {
+	list := make([]Log, len(logs))
+	for i, log := range logs {
+		list[i].Log = log
+	}
+	return list
+}
+
+func ContextToBson(c *LogContext) bson.D {
+	document := bson.D{
+		{Key: "block_timestamp", Value: int64(c.BlockTimestamp)},
+		{Key: "from", Value: c.From},
+		{Key: "to", Value: nil},
+		{Key: "status", Value: int64(c.Status)},
+		{Key: "gas_used", Value: int64(c.GasUsed)},
+	}
+	if c.To != nil {
+		document[2].Value = *c.To
+	}
+	return document
+}
+
+func ContextFromBsonM(data bson.M) *LogContext {
+	c := new(LogContext)
+	if number, ok := data["block_timestamp"].(int64); ok {
+		c.BlockTimestamp = uint64(number)
+	}
+	if binary, ok := data["from"].(primitive.Binary); ok {
+		c.From = common.BytesToAddress(binary.Data)
+	}
+	if binary, ok := data["to"].(primitive.Binary); ok {
+		to := common.BytesToAddress(binary.Data)
+		c.To = &to
+	}
+	if number, ok := data["status"].(int64); ok {
+		c.Status = uint64(number)
+	}
+	if number, ok := data["gas_used"].(int64); ok {
+		c.GasUsed = uint64(number)
+	}
+	return c
+}
+
+func ContextToProtobuf(c *LogContext) *logger.LogContext {
+	if c == nil {
+		return nil
+	}
+	message := &logger.LogContext{
+		BlockTimestamp: c.BlockTimestamp,
+		From:           c.From.Bytes(),
+		Status:         c.Status,
+		GasUsed:        c.GasUsed,
+	}
+	if c.To != nil {
+		message.To = c.To.Bytes()
+	}
+	return message
+}
+
+func ContextFromProtobuf(c *logger.LogContext) *LogContext {
+	if c == nil {
+		return nil
+	}
+	context := &LogContext{
+		BlockTimestamp: c.BlockTimestamp,
+		From:           common.BytesToAddress(c.From),
+		Status:         c.Status,
+		GasUsed:        c.GasUsed,
+	}
+	if len(c.To) != 0 {
+		to := common.BytesToAddress(c.To)
+		context.To = &to
+	}
+	return context
+}
@@ -0,0 +1,159 @@
+package logtypes
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// LogJSON 은 export 파일(jsonl)의 한 줄이다. 숫자는 10진수, 해시/주소/데이터는 0x 로 시작하는 hex 문자열이다.
+type LogJSON struct {
+	ChainID     uint64         `json:"chainId"`
+	BlockNumber uint64         `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	Index       uint           `json:"logIndex"`
+	TxHash      common.Hash    `json:"transactionHash"`
+	TxIndex     uint           `json:"transactionIndex"`
+	Address     common.Address `json:"address"`
+	Topics      []common.Hash  `json:"topics"`
+	Data        hexutil.Bytes  `json:"data"`
+	Context     *ContextJSON   `json:"context,omitempty"`
+}
+
+type ContextJSON struct {
+	BlockTimestamp uint64          `json:"blockTimestamp"`
+	From           common.Address  `json:"from"`
+	To             *common.Address `json:"to"`
+	Status         uint64          `json:"status"`
+	GasUsed        uint64          `json:"gasUsed"`
+}
+
+func LogToJSON(chainID uint64, log Log) LogJSON {
+	j := LogJSON{
+		ChainID:     chainID,
+		BlockNumber: log.BlockNumber,
+		BlockHash:   log.BlockHash,
+		Index:       log.Index,
+		TxHash:      log.TxHash,
+		TxIndex:     log.TxIndex,
+		Address:     log.Address,
+		Topics:      log.Topics,
+		Data:        log.Data,
+	}
+	if j.Topics == nil {
+		j.Topics = []common.Hash{}
+	}
+	if c := log.Context; c != nil {
+		j.Context = &ContextJSON{BlockTimestamp: c.BlockTimestamp, From: c.From, To: c.To, Status: c.Status, GasUsed: c.GasUsed}
+	}
+	return j
+}
+
+func LogFromJSON(j LogJSON) (uint64, Log) {
+	log := Log{Log: types.Log{
+		BlockNumber: j.BlockNumber,
+		BlockHash:   j.BlockHash,
+		Index:       j.Index,
+		TxHash:      j.TxHash,
+		TxIndex:     j.TxIndex,
+		Address:     j.Address,
+		Topics:      j.Topics,
+		Data:        j.Data,
+	}}
+	if c := j.Context; c != nil {
+		log.Context = &LogContext{BlockTimestamp: c.BlockTimestamp, From: c.From, To: c.To, Status: c.Status, GasUsed: c.GasUsed}
+	}
+	return j.ChainID, log
+}
+
+// CSVHeader 는 export 파일(csv)의 컬럼이다. 사용하지 않는 토픽과 보강되지 않은 로그의 context 컬럼은 빈 값이다.
+var CSVHeader = []string{
+	"chain_id", "block_number", "block_hash", "log_index", "tx_hash", "tx_index", "address",
+	"topic0", "topic1", "topic2", "topic3", "data",
+	"block_timestamp", "from", "to", "status", "gas_used",
+}
+
+func LogToCSV(chainID uint64, log Log) []string {
+	record := make([]string, 0, len(CSVHeader))
+	record = append(record,
+		strconv.FormatUint(chainID, 10),
+		strconv.FormatUint(log.BlockNumber, 10),
+		log.BlockHash.Hex(),
+		strconv.FormatUint(uint64(log.Index), 10),
+		log.TxHash.Hex(),
+		strconv.FormatUint(uint64(log.TxIndex), 10),
+		log.Address.Hex(),
+	)
+	for i := 0; i < 4; i++ {
+		if i < len(log.Topics) {
+			record = append(record, log.Topics[i].Hex())
+		} else {
+			record = append(record, "")
+		}
+	}
+	record = append(record, hexutil.Encode(log.Data))
+	if c := log.Context; c != nil {
+		to := ""
+		if c.To != nil {
+			to = c.To.Hex()
+		}
+		record = append(record,
+			strconv.FormatUint(c.BlockTimestamp, 10),
+			c.From.Hex(),
+			to,
+			strconv.FormatUint(c.Status, 10),
+			strconv.FormatUint(c.GasUsed, 10),
+		)
+	} else {
+		record = append(record, "", "", "", "", "")
+	}
+	return record
+}
+
+func LogFromCSV(record []string) (uint64, Log, error) {
+	if len(record) != len(CSVHeader) {
+		return 0, Log{}, errors.New("invalid csv record: column count mismatch")
+	}
+	var err error
+	number := func(column int) uint64 {
+		value, e := strconv.ParseUint(record[column], 10, 64)
+		if e != nil && err == nil {
+			err = errors.New("invalid csv record: " + CSVHeader[column])
+		}
+		return value
+	}
+
+	chainID, log := number(0), Log{}
+	log.BlockNumber, log.BlockHash, log.Index = number(1), common.HexToHash(record[2]), uint(number(3))
+	log.TxHash, log.TxIndex, log.Address = common.HexToHash(record[4]), uint(number(5)), common.HexToAddress(record[6])
+	for _, topic := range record[7:11] {
+		if topic == "" {
+			break
+		}
+		log.Topics = append(log.Topics, common.HexToHash(topic))
+	}
+	data, e := hexutil.Decode(record[11])
+	if e != nil {
+		return 0, Log{}, errors.New("invalid csv record: data")
+	}
+	log.Data = data
+	if record[12] != "" {
+		log.Context = &LogContext{
+			BlockTimestamp: number(12),
+			From:           common.HexToAddress(record[13]),
+			Status:         number(15),
+			GasUsed:        number(16),
+		}
+		if record[14] != "" {
+			to := common.HexToAddress(record[14])
+			log.Context.To = &to
+		}
+	}
+	if err != nil {
+		return 0, Log{}, err
+	}
+	return chainID, log, nil
+}
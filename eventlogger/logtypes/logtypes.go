@@ -1,6 +1,8 @@
 package logtypes
 
 import (
+	"math/big"
+
 	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -9,11 +11,9 @@ import (
 )
 
 func LogToBson(log types.Log) bson.D {
-	bnh, bnl := SplitUint64(log.BlockNumber)
 	return bson.D{
 		{Key: "raw", Value: bson.D{
-			{Key: "block_number_high", Value: bnh},
-			{Key: "block_number_low", Value: bnl},
+			{Key: "block_number", Value: BlockNumberToBson(log.BlockNumber)},
 			{Key: "block_hash", Value: log.BlockHash},
 			{Key: "index", Value: int64(log.Index)},
 			{Key: "tx_hash", Value: log.TxHash},
@@ -44,14 +44,19 @@ func LogFromBsonM(data bson.M) types.Log {
 
 	if rawData, ok := data["raw"]; ok {
 		if raw, ok := rawData.(bson.M); ok {
-			var high, low int64 = 0, 0
-			if data, ok := raw["block_number_high"]; ok {
-				high, _ = data.(int64)
-			}
-			if data, ok := raw["block_number_low"]; ok {
-				low, _ = data.(int64)
+			if data, ok := raw["block_number"]; ok {
+				log.BlockNumber = BlockNumberFromBson(data)
+			} else {
+				// SplitUint64 로 저장된 이전 버전의 문서
+				var high, low int64 = 0, 0
+				if data, ok := raw["block_number_high"]; ok {
+					high, _ = data.(int64)
+				}
+				if data, ok := raw["block_number_low"]; ok {
+					low, _ = data.(int64)
+				}
+				log.BlockNumber = JoinUint64(high, low)
 			}
-			log.BlockNumber = JoinUint64(high, low)
 
 			if data, ok := raw["block_hash"]; ok {
 				if binary, ok := data.(primitive.Binary); ok {
@@ -140,6 +145,26 @@ func LogFromProtobuf(log *logger.Log) types.Log {
 	}
 }
 
+// BlockNumberToBson 은 uint64 전체 범위에서 대소 비교가 가능하도록 블록 번호를 Decimal128 로 변환한다.
+func BlockNumberToBson(number uint64) primitive.Decimal128 {
+	value, _ := primitive.ParseDecimal128FromBigInt(new(big.Int).SetUint64(number), 0)
+	return value
+}
+
+func BlockNumberFromBson(data interface{}) uint64 {
+	switch number := data.(type) {
+	case primitive.Decimal128:
+		if value, _, err := number.BigInt(); err == nil {
+			return value.Uint64()
+		}
+	case int64:
+		return uint64(number)
+	case int32:
+		return uint64(number)
+	}
+	return 0
+}
+
 func SplitUint64(x uint64) (high int64, low int64) {
 	high = int64(x >> 32)
 	low = int64(x & 0xFFFFFFFF)
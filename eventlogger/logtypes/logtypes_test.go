@@ -34,9 +34,7 @@ func TestBSON(t *testing.T) {
 	raw, ok := data["raw"].(bson.M)
 	require.True(t, ok)
 	{ // raw
-		_, ok = raw["block_number_high"].(int64)
-		require.True(t, ok)
-		_, ok = raw["block_number_low"].(int64)
+		_, ok = raw["block_number"].(primitive.Decimal128)
 		require.True(t, ok)
 		_, ok = raw["block_hash"].(primitive.Binary)
 		require.True(t, ok)
@@ -84,3 +82,70 @@ func TestProtobuf(t *testing.T) {
 	require.Equal(t, log, from)
 	require.True(t, reflect.DeepEqual(log, from))
 }
+
+func TestTopics(t *testing.T) {
+	a, b, c := common.Hash{1}, common.Hash{2}, common.Hash{3}
+	log := types.Log{Topics: []common.Hash{a, b}}
+
+	for _, tc := range []struct {
+		topics [][]common.Hash
+		match  bool
+	}{
+		{nil, true},
+		{[][]common.Hash{{a}}, true},
+		{[][]common.Hash{{c, a}}, true},
+		{[][]common.Hash{nil, {b}}, true},
+		{[][]common.Hash{{a}, {c}}, false},
+		{[][]common.Hash{{b}}, false},
+		{[][]common.Hash{nil, nil, nil}, false},
+	} {
+		require.Equal(t, tc.match, logtypes.MatchTopics(log, tc.topics), tc.topics)
+		require.Equal(t, tc.topics, logtypes.TopicsFromProtobuf(logtypes.TopicsToProtobuf(tc.topics)))
+	}
+
+	filter, err := bson.Marshal(logtypes.TopicsToBson([][]common.Hash{nil, {b, c}}))
+	require.NoError(t, err)
+	data := bson.M{}
+	require.NoError(t, bson.Unmarshal(filter, &data))
+	require.Contains(t, data, "topics.0")
+	in, ok := data["topics.1"].(bson.M)["$in"].(primitive.A)
+	require.True(t, ok)
+	require.Equal(t, 2, len(in))
+}
+
+func TestCursor(t *testing.T) {
+	cursor := logtypes.Cursor{BlockNumber: math.MaxUint64, Index: 7}
+	parsed, err := logtypes.CursorFromToken(cursor.Token())
+	require.NoError(t, err)
+	require.Equal(t, cursor, parsed)
+
+	_, err = logtypes.CursorFromToken("invalid")
+	require.ErrorIs(t, err, logtypes.ErrInvalidCursorToken)
+
+	require.True(t, logtypes.Cursor{1, 2}.Less(logtypes.Cursor{1, 3}))
+	require.True(t, logtypes.Cursor{1, 9}.Less(logtypes.Cursor{2, 0}))
+	require.False(t, logtypes.Cursor{2, 0}.Less(logtypes.Cursor{2, 0}))
+
+	require.Equal(t, logtypes.Cursor{1, 2}, logtypes.Cursor{1, 3}.Prev())
+	require.True(t, logtypes.Cursor{1, 0}.Prev().Less(logtypes.Cursor{1, 0}))
+	require.False(t, logtypes.Cursor{1, 0}.Prev().Less(logtypes.Cursor{0, 1 << 20}))
+	require.Equal(t, cursor, logtypes.CursorFromProtobuf(logtypes.CursorToProtobuf(cursor)))
+}
+
+func TestContext(t *testing.T) {
+	to := common.HexToAddress("0x2")
+	for _, c := range []*logtypes.LogContext{
+		{BlockTimestamp: 1700000000, From: common.HexToAddress("0x1"), To: &to, Status: types.ReceiptStatusSuccessful, GasUsed: 21000},
+		{BlockTimestamp: 1700000000, From: common.HexToAddress("0x1"), Status: types.ReceiptStatusFailed}, // 컨트랙트 생성
+	} {
+		bytes, err := bson.Marshal(logtypes.ContextToBson(c))
+		require.NoError(t, err)
+		data := bson.M{}
+		require.NoError(t, bson.Unmarshal(bytes, &data))
+		require.Equal(t, c, logtypes.ContextFromBsonM(data))
+
+		require.Equal(t, c, logtypes.ContextFromProtobuf(logtypes.ContextToProtobuf(c)))
+	}
+	require.Nil(t, logtypes.ContextToProtobuf(nil))
+	require.Nil(t, logtypes.ContextFromProtobuf(nil))
+}
@@ -0,0 +1,91 @@
+package logtypes
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var ErrInvalidCursorToken = errors.New("invalid cursor token")
+
+// Cursor 는 로그의 정렬 위치 (block, logIndex) 이다.
+type Cursor struct {
+	BlockNumber uint64
+	Index       uint
+}
+
+func CursorOf(log types.Log) Cursor {
+	return Cursor{log.BlockNumber, log.Index}
+}
+
+func (c Cursor) Less(o Cursor) bool {
+	return c.BlockNumber < o.BlockNumber || (c.BlockNumber == o.BlockNumber && c.Index < o.Index)
+}
+
+// Prev 는 c 바로 앞의 위치를 반환한다. (block, 0) 의 앞은 (block-1, max(uint32)) 이다.
+func (c Cursor) Prev() Cursor {
+	if c.Index > 0 {
+		return Cursor{c.BlockNumber, c.Index - 1}
+	} else if c.BlockNumber > 0 {
+		return Cursor{c.BlockNumber - 1, math.MaxUint32}
+	}
+	return c
+}
+
+func CursorToProtobuf(c Cursor) *logger.Cursor {
+	return &logger.Cursor{BlockNumber: c.BlockNumber, Index: uint32(c.Index)}
+}
+
+func CursorFromProtobuf(c *logger.Cursor) Cursor {
+	return Cursor{BlockNumber: c.GetBlockNumber(), Index: uint(c.GetIndex())}
+}
+
+// Token 은 페이지 토큰으로 사용할 수 있는 문자열을 반환한다.
+func (c Cursor) Token() string {
+	bytes := make([]byte, 12)
+	binary.BigEndian.PutUint64(bytes, c.BlockNumber)
+	binary.BigEndian.PutUint32(bytes[8:], uint32(c.Index))
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+func CursorFromToken(token string) (Cursor, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(bytes) != 12 {
+		return Cursor{}, ErrInvalidCursorToken
+	}
+	return Cursor{
+		BlockNumber: binary.BigEndian.Uint64(bytes),
+		Index:       uint(binary.BigEndian.Uint32(bytes[8:])),
+	}, nil
+}
+
+// SortToBson 은 로그를 (block, logIndex) 순서로 정렬한다. (order: 1 오름차순, -1 내림차순)
+func SortToBson(order int) bson.D {
+	return bson.D{
+		{Key: "raw.block_number", Value: order},
+		{Key: "raw.index", Value: order},
+	}
+}
+
+// BlockRangeToBson 은 from <= block <= to 조건을 반환한다. to 가 0 이면 상한을 두지 않는다.
+func BlockRangeToBson(from, to uint64) bson.D {
+	condition := bson.D{{Key: "$gte", Value: BlockNumberToBson(from)}}
+	if to != 0 {
+		condition = append(condition, bson.E{Key: "$lte", Value: BlockNumberToBson(to)})
+	}
+	return bson.D{{Key: "raw.block_number", Value: condition}}
+}
+
+// AfterCursorToBson 은 cursor 이후에 위치한 로그의 조건을 반환한다.
+func AfterCursorToBson(cursor Cursor) bson.D {
+	number := BlockNumberToBson(cursor.BlockNumber)
+	return bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "raw.block_number", Value: bson.D{{Key: "$gt", Value: number}}}},
+		bson.D{{Key: "raw.block_number", Value: number}, {Key: "raw.index", Value: bson.D{{Key: "$gt", Value: int64(cursor.Index)}}}},
+	}}}
+}
@@ -0,0 +1,73 @@
+package logtypes
+
+import (
+	"strconv"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TopicsToProtobuf(topics [][]common.Hash) []*logger.Topics {
+	list := make([]*logger.Topics, len(topics))
+	for i, position := range topics {
+		hashes := make([][]byte, len(position))
+		for j, topic := range position {
+			hashes[j] = topic.Bytes()
+		}
+		list[i] = &logger.Topics{Topic: hashes}
+	}
+	return list
+}
+
+func TopicsFromProtobuf(topics []*logger.Topics) [][]common.Hash {
+	if len(topics) == 0 {
+		return nil
+	}
+	list := make([][]common.Hash, len(topics))
+	for i, position := range topics {
+		for _, topic := range position.GetTopic() {
+			list[i] = append(list[i], common.BytesToHash(topic))
+		}
+	}
+	return list
+}
+
+// MatchTopics 는 ethereum.FilterQuery.Topics 와 같은 규칙으로 로그의 토픽을 확인한다.
+// 각 위치의 목록 중 하나와 일치해야 하며, 빈 목록은 모든 토픽과 일치한다.
+func MatchTopics(log types.Log, topics [][]common.Hash) bool {
+	if len(topics) > len(log.Topics) {
+		return false
+	}
+	for i, position := range topics {
+		if len(position) == 0 {
+			continue
+		}
+		match := false
+		for _, topic := range position {
+			if log.Topics[i] == topic {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	return true
+}
+
+// TopicsToBson 은 MatchTopics 와 같은 규칙의 mongo 조회 조건을 반환한다.
+func TopicsToBson(topics [][]common.Hash) bson.D {
+	filter := bson.D{}
+	for i, position := range topics {
+		key := "topics." + strconv.Itoa(i)
+		if len(position) == 0 {
+			filter = append(filter, bson.E{Key: key, Value: bson.D{{Key: "$exists", Value: true}}})
+		} else {
+			filter = append(filter, bson.E{Key: key, Value: bson.D{{Key: "$in", Value: position}}})
+		}
+	}
+	return filter
+}
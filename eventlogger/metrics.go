@@ -0,0 +1,152 @@
+package eventlogger
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logabi"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logtypes"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const metricsNamespace = "eventlogger"
+
+// 모든 메트릭은 chain_id 레이블로 체인을 구분한다.
+var (
+	blocksScannedMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "blocks_scanned_total",
+		Help:      "Number of blocks whose logs are collected. Blocks scanned again after a reorg are counted again.",
+	}, []string{"chain_id"})
+	headBlockMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "head_block",
+		Help:      "Latest block number received from the node.",
+	}, []string{"chain_id"})
+	scanBlockMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "scan_block",
+		Help:      "Last block number whose logs are stored.",
+	}, []string{"chain_id"})
+	lagMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "lag_blocks",
+		Help:      "Number of blocks between the head block and the scan block, including the confirmations.",
+	}, []string{"chain_id"})
+	logsStoredMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "logs_stored_total",
+		Help:      "Number of logs stored per contract address.",
+	}, []string{"chain_id", "address"})
+	filterLogsDurationMetric = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "filter_logs_duration_seconds",
+		Help:      "Latency of eth_getLogs requests.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"chain_id"})
+	filterLogsErrorsMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "filter_logs_errors_total",
+		Help:      "Number of failed eth_getLogs requests.",
+	}, []string{"chain_id"})
+	streamClientsMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "stream_clients",
+		Help:      "Number of clients connected to the log stream.",
+	}, []string{"chain_id"})
+	decodeFailuresMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "decode_failures_total",
+		Help:      "Number of logs that match a registered event signature but fail to decode.",
+	}, []string{"chain_id", "event"})
+)
+
+// chainMetrics 는 체인 하나의 레이블이 적용된 메트릭이다.
+type chainMetrics struct {
+	blocksScanned      prometheus.Counter
+	headBlock          prometheus.Gauge
+	scanBlock          prometheus.Gauge
+	lag                prometheus.Gauge
+	logsStored         *prometheus.CounterVec
+	filterLogsDuration prometheus.Observer
+	filterLogsErrors   prometheus.Counter
+	streamClients      prometheus.Gauge
+	decodeFailures     *prometheus.CounterVec
+
+	// 지연 블록 수를 계산하기 위한 값으로, 수집 고루틴에서만 사용된다.
+	head, scanned uint64
+}
+
+func newChainMetrics(chainID uint64) *chainMetrics {
+	label := prometheus.Labels{"chain_id": strconv.FormatUint(chainID, 10)}
+	return &chainMetrics{
+		blocksScanned:      blocksScannedMetric.With(label),
+		headBlock:          headBlockMetric.With(label),
+		scanBlock:          scanBlockMetric.With(label),
+		lag:                lagMetric.With(label),
+		logsStored:         logsStoredMetric.MustCurryWith(label),
+		filterLogsDuration: filterLogsDurationMetric.With(label),
+		filterLogsErrors:   filterLogsErrorsMetric.With(label),
+		streamClients:      streamClientsMetric.With(label),
+		decodeFailures:     decodeFailuresMetric.MustCurryWith(label),
+	}
+}
+
+// observeHead 는 노드로부터 받은 최신 블록 번호를 기록한다.
+func (m *chainMetrics) observeHead(head uint64) {
+	m.head = head
+	m.headBlock.Set(float64(head))
+	m.updateLag()
+}
+
+// observeScan 은 저장된 마지막 블록 번호를 기록한다.
+func (m *chainMetrics) observeScan(number uint64) {
+	m.scanned = number
+	m.scanBlock.Set(float64(number))
+	m.updateLag()
+}
+
+func (m *chainMetrics) updateLag() {
+	if m.head > m.scanned {
+		m.lag.Set(float64(m.head - m.scanned))
+	} else {
+		m.lag.Set(0)
+	}
+}
+
+// observeStored 는 저장된 로그의 수를 주소별로 기록한다.
+func (m *chainMetrics) observeStored(logs []logtypes.Log) {
+	for _, log := range logs {
+		m.logsStored.WithLabelValues(log.Address.Hex()).Inc()
+	}
+}
+
+// observeDecode 는 이벤트 시그니처가 일치하지만 디코딩하지 못한 로그를 이벤트별로 기록한다.
+func (m *chainMetrics) observeDecode(err error) {
+	var decodeErr *logabi.DecodeError
+	if errors.As(err, &decodeErr) {
+		m.decodeFailures.WithLabelValues(decodeErr.Signature).Inc()
+	}
+}
+
+// observeHead 는 마지막으로 받은 헤드를 Status 와 메트릭에 기록한다.
+func (s *chainLogger) observeHead(head uint64) {
+	s.lastHead.Store(head)
+	s.metrics.observeHead(head)
+}
+
+// filterLogs 는 노드의 FilterLogs 를 호출하고 소요 시간과 실패를 기록한다.
+func (s *chainLogger) filterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	started := time.Now()
+	logs, err := s.client.FilterLogs(ctx, q)
+	s.metrics.filterLogsDuration.Observe(time.Since(started).Seconds())
+	if err != nil && ctx.Err() == nil {
+		s.metrics.filterLogsErrors.Inc()
+	}
+	return logs, err
+}
@@ -0,0 +1,109 @@
+package eventlogger_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logabi"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logstore"
+	"github.com/bang9ming9/bm-cli-tool/metrics"
+	abis "github.com/bang9ming9/bm-governance/abis"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// scrapeMetrics 는 /metrics 를 읽어 "<name>{<labels>}" 별 값을 반환한다.
+func scrapeMetrics(t *testing.T, url string) map[string]float64 {
+	res, err := http.Get(url + "/metrics")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	values := make(map[string]float64)
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.LastIndexByte(line, ' ')
+		value, err := strconv.ParseFloat(line[i+1:], 64)
+		require.NoError(t, err, line)
+		values[line[:i]] = value
+	}
+	require.NoError(t, scanner.Err())
+	return values
+}
+
+func TestMetrics(t *testing.T) {
+	server := httptest.NewServer(metrics.Handler())
+	defer server.Close()
+
+	// 로그의 topic0 은 Transfer 이지만 indexed 인자가 없어 디코딩에 실패한다.
+	erc20ABI, err := abis.BmErc20MetaData.GetAbi()
+	require.NoError(t, err)
+	registry := logabi.NewRegistry()
+	registry.Register(nil, *erc20ABI)
+	transfer := erc20ABI.Events["Transfer"]
+
+	chain, store := newFakeChain(5), logstore.NewMemoryStore()
+	chain.topics = []common.Hash{transfer.ID}
+	stop := startFakeChainServer(t, "localhost:50641", chain, store, &eventlogger.Options{Confirmations: 2, Registry: registry})
+	defer stop()
+	waitCheckpoint(t, store, 3)
+
+	conn, err := grpc.NewClient("localhost:50641", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := logger.NewLoggerClient(conn).Connect(ctx, &logger.ConnectReqMessage{Addresses: [][]byte{fakeLogAddress.Bytes()}, Decode: true})
+	require.NoError(t, err)
+
+	const chainID = `chain_id="1337"`
+	var (
+		clients       = "eventlogger_stream_clients{" + chainID + "}"
+		blocksScanned = "eventlogger_blocks_scanned_total{" + chainID + "}"
+		logsStored    = fmt.Sprintf(`eventlogger_logs_stored_total{address="%s",%s}`, fakeLogAddress.Hex(), chainID)
+		decodeFailure = fmt.Sprintf(`eventlogger_decode_failures_total{%s,event="%s"}`, chainID, transfer.Sig)
+	)
+	var before map[string]float64
+	require.Eventually(t, func() bool {
+		before = scrapeMetrics(t, server.URL)
+		return before[clients] == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// 새로운 헤드의 4번 블록 로그 하나를 수집하여 클라이언트에 전달한다.
+	chain.heads <- chain.mine()
+	log, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), log.Raw.BlockNumber)
+	require.Nil(t, log.Event)
+
+	after := scrapeMetrics(t, server.URL)
+	require.Equal(t, float64(6), after["eventlogger_head_block{"+chainID+"}"])
+	require.Equal(t, float64(4), after["eventlogger_scan_block{"+chainID+"}"])
+	require.Equal(t, float64(2), after["eventlogger_lag_blocks{"+chainID+"}"])
+	require.Equal(t, float64(1), after[clients])
+	// 카운터는 다른 테스트의 같은 체인과 공유되므로 증가량을 비교한다.
+	require.Equal(t, float64(1), after[blocksScanned]-before[blocksScanned])
+	require.Equal(t, float64(1), after[logsStored]-before[logsStored])
+	require.Equal(t, float64(1), after[decodeFailure]-before[decodeFailure])
+	require.Contains(t, after, "eventlogger_filter_logs_duration_seconds_count{"+chainID+"}")
+
+	cancel()
+	require.Eventually(t, func() bool {
+		return scrapeMetrics(t, server.URL)[clients] == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,129 @@
+package eventlogger_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logtypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// blockedStream 은 release 가 닫힐 때까지 Send 를 멈추는 클라이언트 스트림이다.
+type blockedStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	sending chan struct{} // Send 가 호출될 때마다 알린다.
+	release chan struct{}
+	sent    chan *logger.Log
+}
+
+func newBlockedStream(ctx context.Context) *blockedStream {
+	return &blockedStream{ctx: ctx, sending: make(chan struct{}, 16), release: make(chan struct{}), sent: make(chan *logger.Log, 16)}
+}
+
+func (s *blockedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *blockedStream) Send(log *logger.Log) error {
+	s.sending <- struct{}{}
+	select {
+	case <-s.release:
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+	s.sent <- log
+	return nil
+}
+
+// connectBlocked 는 전송 큐의 크기가 2 인 클라이언트를 연결하고, 첫번째 로그의 전송이 멈출 때까지 기다린다.
+func connectBlocked(t *testing.T, policy eventlogger.OverflowPolicy) (*eventlogger.TestChain, *blockedStream, context.CancelFunc, chan error) {
+	chain := eventlogger.NewTestChain(1337, []common.Address{fakeLogAddress}, 2, policy)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := newBlockedStream(ctx)
+	done := make(chan error, 1)
+	go func() {
+		done <- chain.Connect(&logger.ConnectReqMessage{Addresses: [][]byte{fakeLogAddress.Bytes()}}, stream)
+	}()
+	require.Eventually(t, func() bool { return chain.Clients() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	chain.Broadcast(overflowLog(1))
+	select {
+	case <-stream.sending:
+	case <-time.After(5 * time.Second):
+		t.Fatal("log is not sent")
+	}
+	return chain, stream, cancel, done
+}
+
+func overflowLog(number uint64) logtypes.Log {
+	return logtypes.Log{Log: types.Log{Address: fakeLogAddress, Topics: []common.Hash{{0x01}}, BlockNumber: number}}
+}
+
+func TestOverflowPolicy(t *testing.T) {
+	t.Run("Disconnect", func(t *testing.T) {
+		chain, stream, cancel, done := connectBlocked(t, eventlogger.OverflowDisconnect)
+		defer cancel()
+
+		// 2개는 큐에 쌓이고, 3번째 로그에서 큐가 넘친다.
+		for number := uint64(2); number <= 4; number++ {
+			chain.Broadcast(overflowLog(number))
+		}
+		close(stream.release)
+
+		select {
+		case err := <-done:
+			require.Equal(t, codes.ResourceExhausted, status.Code(err))
+		case <-time.After(5 * time.Second):
+			t.Fatal("slow client is not disconnected")
+		}
+		require.Equal(t, 0, chain.Clients())
+		// 연결이 끊어지기 전에 큐에 쌓인 로그까지만 전달될 수 있다.
+		require.LessOrEqual(t, len(stream.sent), 3)
+		for len(stream.sent) != 0 {
+			require.Less(t, (<-stream.sent).GetRaw().GetBlockNumber(), uint64(4))
+		}
+	})
+	t.Run("Drop", func(t *testing.T) {
+		chain, stream, cancel, done := connectBlocked(t, eventlogger.OverflowDrop)
+
+		// 2개는 큐에 쌓이고, 나머지 3개는 버려진다.
+		for number := uint64(2); number <= 6; number++ {
+			chain.Broadcast(overflowLog(number))
+		}
+		close(stream.release)
+
+		recv := func() *logger.Log {
+			select {
+			case log := <-stream.sent:
+				return log
+			case <-time.After(5 * time.Second):
+				t.Fatal("log is not sent")
+				return nil
+			}
+		}
+		for _, expected := range []struct{ number, dropped uint64 }{{1, 0}, {2, 3}, {3, 0}} {
+			log := recv()
+			require.Equal(t, expected.number, log.GetRaw().GetBlockNumber())
+			require.Equal(t, expected.dropped, log.Dropped, "block %d", expected.number)
+		}
+
+		// 연결은 유지되고, 다음 로그는 버려진 로그 없이 전달된다.
+		require.Equal(t, 1, chain.Clients())
+		chain.Broadcast(overflowLog(7))
+		log := recv()
+		require.Equal(t, uint64(7), log.GetRaw().GetBlockNumber())
+		require.Zero(t, log.Dropped)
+
+		cancel()
+		require.NoError(t, <-done)
+	})
+}
@@ -0,0 +1,75 @@
+package eventlogger
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+const (
+	defaultPollInterval = 2 * time.Second
+	pollTimeout         = 10 * time.Second
+)
+
+// PollingBackend 는 구독을 지원하지 않는 http 엔드포인트를 위해
+// SubscribeNewHead 를 eth_blockNumber 폴링으로 대체한다.
+type PollingBackend struct {
+	Backend
+	interval time.Duration
+}
+
+func NewPollingBackend(client Backend, interval time.Duration) *PollingBackend {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	return &PollingBackend{client, interval}
+}
+
+// SubscribeNewHead 는 interval 마다 최신 블록 번호를 확인하고, 번호가 증가했을때 최신 헤더만 전달한다.
+// 입력된 ctx 는 구독 생성에만 사용된다.
+func (b *PollingBackend) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	if _, err := b.BlockNumber(ctx); err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		ticker := time.NewTicker(b.interval)
+		defer ticker.Stop()
+
+		var next uint64 // 다음으로 전달할 최소 블록 번호
+		for {
+			select {
+			case <-quit:
+				return nil
+			case <-ticker.C:
+				header, err := b.poll(next)
+				if err != nil {
+					return err
+				}
+				if header == nil {
+					continue
+				}
+				select {
+				case ch <- header:
+					next = header.Number.Uint64() + 1
+				case <-quit:
+					return nil
+				}
+			}
+		}
+	}), nil
+}
+
+func (b *PollingBackend) poll(next uint64) (*types.Header, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), pollTimeout)
+	defer cancel()
+
+	number, err := b.BlockNumber(ctx)
+	if err != nil || number < next {
+		return nil, err
+	}
+	return b.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+}
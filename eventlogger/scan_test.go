@@ -0,0 +1,442 @@
+package eventlogger_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logstore"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logtypes"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// fakeChain 은 메모리에서 블록을 만드는 체인이다. 블록마다 fakeLogAddress 의 로그를 하나씩 가진다.
+type fakeChain struct {
+	eventlogger.Backend
+
+	lock        sync.Mutex
+	headers     []*types.Header // 블록 번호 순서
+	fork        byte            // 재조직된 블록을 구분한다.
+	headerErr   func(number uint64) error
+	filterErr   func(q ethereum.FilterQuery) error
+	topics      []common.Hash // 로그의 topics, nil 이면 {0x01}
+	headerCalls map[uint64]int
+	queries     []ethereum.FilterQuery
+
+	heads chan *types.Header
+}
+
+var fakeLogAddress = common.Address{0xfa, 0xce}
+
+func newFakeChain(head uint64) *fakeChain {
+	c := &fakeChain{headerCalls: make(map[uint64]int), heads: make(chan *types.Header)}
+	c.headers = []*types.Header{{Number: new(big.Int)}}
+	c.extend(head)
+	return c
+}
+
+// extend 는 head 블록까지 블록을 만든다.
+func (c *fakeChain) extend(head uint64) {
+	for number := uint64(len(c.headers)); number <= head; number++ {
+		c.headers = append(c.headers, &types.Header{
+			Number:     new(big.Int).SetUint64(number),
+			ParentHash: c.headers[number-1].Hash(),
+			Extra:      []byte{c.fork},
+		})
+	}
+}
+
+// reorg 는 from 블록부터 head 블록까지 새로운 블록으로 바꾼다.
+func (c *fakeChain) reorg(from, head uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.fork++
+	c.headers = c.headers[:from]
+	c.extend(head)
+}
+
+// mine 은 블록을 하나 더 만들고 새로운 헤드를 반환한다.
+func (c *fakeChain) mine() *types.Header {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.extend(uint64(len(c.headers)))
+	return c.headers[len(c.headers)-1]
+}
+
+// newHead 는 마지막 블록을 새로운 헤드로 알린다.
+func (c *fakeChain) newHead() {
+	c.lock.Lock()
+	head := c.headers[len(c.headers)-1]
+	c.lock.Unlock()
+	c.heads <- head
+}
+
+func (c *fakeChain) header(number uint64) *types.Header {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.headers[number]
+}
+
+func (c *fakeChain) calls(number uint64) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.headerCalls[number]
+}
+
+func (c *fakeChain) ChainID(context.Context) (*big.Int, error) {
+	return big.NewInt(1337), nil
+}
+
+func (c *fakeChain) BlockNumber(context.Context) (uint64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return uint64(len(c.headers) - 1), nil
+}
+
+func (c *fakeChain) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	n := number.Uint64()
+	c.headerCalls[n]++
+	if c.headerErr != nil {
+		if err := c.headerErr(n); err != nil {
+			return nil, err
+		}
+	}
+	if n >= uint64(len(c.headers)) {
+		return nil, ethereum.NotFound
+	}
+	return c.headers[n], nil
+}
+
+func (c *fakeChain) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.queries = append(c.queries, q)
+	if c.filterErr != nil {
+		if err := c.filterErr(q); err != nil {
+			return nil, err
+		}
+	}
+	var headers []*types.Header
+	if q.BlockHash != nil {
+		for _, header := range c.headers {
+			if header.Hash() == *q.BlockHash {
+				headers = append(headers, header)
+			}
+		}
+	} else {
+		for number := q.FromBlock.Uint64(); number <= q.ToBlock.Uint64() && number < uint64(len(c.headers)); number++ {
+			headers = append(headers, c.headers[number])
+		}
+	}
+	logs := []types.Log{}
+	for _, header := range headers {
+		if len(q.Addresses) != 0 && !containsAddress(q.Addresses, fakeLogAddress) {
+			continue
+		}
+		number := header.Number.Uint64()
+		topics := c.topics
+		if topics == nil {
+			topics = []common.Hash{{0x01}}
+		}
+		logs = append(logs, types.Log{
+			Address:     fakeLogAddress,
+			Topics:      topics,
+			Data:        []byte{c.fork},
+			BlockNumber: number,
+			BlockHash:   header.Hash(),
+			TxHash:      common.BigToHash(new(big.Int).SetUint64(number)),
+		})
+	}
+	return logs, nil
+}
+
+func (c *fakeChain) SubscribeNewHead(_ context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		for {
+			select {
+			case head := <-c.heads:
+				select {
+				case ch <- head:
+				case <-quit:
+					return nil
+				}
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+func containsAddress(addresses []common.Address, address common.Address) bool {
+	for _, a := range addresses {
+		if a == address {
+			return true
+		}
+	}
+	return false
+}
+
+// startFakeChainServer 는 fakeChain 의 1번 블록부터 fakeLogAddress 의 로그를 수집하는 서버를 시작한다.
+func startFakeChainServer(t *testing.T, addr string, chain *fakeChain, store logstore.LogStore, options *eventlogger.Options) func() {
+	log := logrus.New()
+	log.SetLevel(logrus.FatalLevel)
+	stopCh := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		require.NoError(t, eventlogger.NewMultiChainLoggerServer(stopCh, addr, log, []eventlogger.Chain{{
+			Client: chain,
+			Store:  store,
+			Query:  &ethereum.FilterQuery{FromBlock: common.Big1, Addresses: []common.Address{fakeLogAddress}},
+		}}, options))
+	}()
+	return func() {
+		stopCh <- os.Interrupt
+		// 수집 고루틴은 다음 블록을 수집한 뒤에 종료된다.
+		for {
+			select {
+			case <-done:
+				return
+			case chain.heads <- chain.mine():
+			}
+		}
+	}
+}
+
+func waitCheckpoint(t *testing.T, store logstore.LogStore, number uint64) logstore.Checkpoint {
+	var checkpoint logstore.Checkpoint
+	require.Eventually(t, func() bool {
+		var err error
+		checkpoint, err = store.LatestCheckpoint(context.Background())
+		require.NoError(t, err)
+		return checkpoint.BlockNumber == number
+	}, 5*time.Second, 10*time.Millisecond)
+	return checkpoint
+}
+
+func TestRollbackFailure(t *testing.T) {
+	chain, store := newFakeChain(5), logstore.NewMemoryStore()
+	stop := startFakeChainServer(t, "localhost:50621", chain, store, nil)
+	defer stop()
+	waitCheckpoint(t, store, 5)
+	// 헤드 단위로 수집된 블록은 모두 재조직 감지에 사용된다.
+	chain.heads <- chain.mine()
+	chain.heads <- chain.mine()
+	waitCheckpoint(t, store, 7)
+	calls := chain.calls(7)
+
+	// 6번 블록부터 재조직되었지만, 공통 조상을 찾는 노드 호출이 실패한다.
+	chain.lock.Lock()
+	chain.headerErr = func(number uint64) error {
+		if number <= 7 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	chain.lock.Unlock()
+	chain.reorg(6, 8)
+	chain.newHead()
+
+	// 같은 재조직을 반복해서 감지하지 않고 다음 헤드를 기다린다.
+	time.Sleep(300 * time.Millisecond)
+	require.Equal(t, 1, chain.calls(8))
+	require.Equal(t, calls+1, chain.calls(7))
+	checkpoint, err := store.LatestCheckpoint(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), checkpoint.BlockNumber)
+
+	// 노드가 복구된 뒤의 헤드에서 공통 조상까지 되돌리고 새로운 블록을 수집한다.
+	chain.lock.Lock()
+	chain.headerErr = nil
+	chain.lock.Unlock()
+	chain.newHead()
+	checkpoint = waitCheckpoint(t, store, 8)
+	require.Equal(t, chain.header(8).Hash(), checkpoint.BlockHash)
+
+	logs, err := store.QueryLogs(context.Background(), logstore.Query{})
+	require.NoError(t, err)
+	require.Len(t, logs, 8)
+	for _, log := range logs {
+		require.Equal(t, chain.header(log.BlockNumber).Hash(), log.BlockHash)
+	}
+}
+
+// failingStore 는 commitErr 가 설정된 동안 Commit 에 실패하는 저장소이다.
+type failingStore struct {
+	logstore.LogStore
+
+	lock      sync.Mutex
+	commitErr error
+}
+
+func (s *failingStore) setCommitErr(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.commitErr = err
+}
+
+func (s *failingStore) Commit(ctx context.Context, logs []logtypes.Log, checkpoint logstore.Checkpoint) error {
+	s.lock.Lock()
+	err := s.commitErr
+	s.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	return s.LogStore.Commit(ctx, logs, checkpoint)
+}
+
+func TestCommitFailure(t *testing.T) {
+	chain, store := newFakeChain(5), &failingStore{LogStore: logstore.NewMemoryStore()}
+	stop := startFakeChainServer(t, "localhost:50622", chain, store, nil)
+	defer stop()
+	waitCheckpoint(t, store, 5)
+
+	conn, err := grpc.NewClient("localhost:50622", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := logger.NewLoggerClient(conn).Connect(ctx, &logger.ConnectReqMessage{Addresses: [][]byte{fakeLogAddress.Bytes()}, FromBlock: 5})
+	require.NoError(t, err)
+	received := make(chan uint64, 16)
+	go func() {
+		for {
+			log, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			received <- log.Raw.BlockNumber
+		}
+	}()
+	// 저장된 로그를 받으면 클라이언트가 등록된 상태이다.
+	require.Equal(t, uint64(5), <-received)
+
+	// 저장에 실패한 블록의 로그는 전달하지 않고, 체크포인트도 움직이지 않는다.
+	store.setCommitErr(errors.New("disk full"))
+	chain.heads <- chain.mine()
+	select {
+	case number := <-received:
+		t.Fatalf("log of block %d is broadcast before it is stored", number)
+	case <-time.After(300 * time.Millisecond):
+	}
+	checkpoint, err := store.LatestCheckpoint(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), checkpoint.BlockNumber)
+	res, err := logger.NewAdminClient(conn).Status(ctx, &logger.ChainReqMessage{})
+	require.NoError(t, err)
+	require.Len(t, res.Errors, 1)
+	require.Equal(t, "commit logs", res.Errors[0].Operation)
+	require.Equal(t, "disk full", res.Errors[0].Message)
+
+	// 다음 헤드에서 저장에 실패한 블록부터 다시 수집한다.
+	store.setCommitErr(nil)
+	chain.heads <- chain.mine()
+	waitCheckpoint(t, store, 7)
+	require.Equal(t, uint64(6), <-received)
+	require.Equal(t, uint64(7), <-received)
+
+	logs, err := store.QueryLogs(context.Background(), logstore.Query{})
+	require.NoError(t, err)
+	require.Len(t, logs, 7)
+}
+
+func TestStartStop(t *testing.T) {
+	// 체크포인트가 없고 수집된 블록도 없어 스캔 블록이 0 인 상태로 시작한다.
+	chain, store := newFakeChain(0), logstore.NewMemoryStore()
+	stop := startFakeChainServer(t, "localhost:50623", chain, store, nil)
+	defer stop()
+
+	conn, err := grpc.NewClient("localhost:50623", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	admin := logger.NewAdminClient(conn)
+	ctx := context.Background()
+	require.Eventually(t, func() bool {
+		res, err := admin.Status(ctx, &logger.ChainReqMessage{})
+		return err == nil && res.Running
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// 스캔 블록과 관계없이 수집중이면 다시 시작하지 않는다.
+	_, err = admin.Start(ctx, &logger.BlockNumberMessage{})
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	// Stop 은 다음 블록을 수집한 뒤에 반환된다.
+	stopScan := func() error {
+		done := make(chan error, 1)
+		go func() {
+			_, err := admin.Stop(ctx, &logger.ChainReqMessage{})
+			done <- err
+		}()
+		for {
+			select {
+			case err := <-done:
+				return err
+			case chain.heads <- chain.mine():
+			}
+		}
+	}
+	require.NoError(t, stopScan())
+	res, err := admin.Status(ctx, &logger.ChainReqMessage{})
+	require.NoError(t, err)
+	require.False(t, res.Running)
+
+	// 멈춘 체인의 Stop 은 기다리지 않고 실패한다.
+	_, err = admin.Stop(ctx, &logger.ChainReqMessage{})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = admin.Start(ctx, &logger.BlockNumberMessage{})
+	require.NoError(t, err)
+	_, err = admin.Start(ctx, &logger.BlockNumberMessage{})
+	require.Equal(t, codes.Aborted, status.Code(err))
+	head, err := chain.BlockNumber(ctx)
+	require.NoError(t, err)
+	waitCheckpoint(t, store, head)
+}
+
+func TestGatewayConnect(t *testing.T) {
+	chain, store := newFakeChain(3), logstore.NewMemoryStore()
+	stop := startFakeChainServer(t, "localhost:50624", chain, store, &eventlogger.Options{HTTPAddr: "localhost:50625"})
+	defer stop()
+	waitCheckpoint(t, store, 3)
+
+	// 연결을 끊은 뒤에도 핸들러가 keepAlive 와 함께 종료되어야 한다.
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost:50625/v1/connect?address="+fakeLogAddress.Hex()+"&fromBlock=1", nil)
+		require.NoError(t, err)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+		var events []string
+		scanner := bufio.NewScanner(res.Body)
+		for len(events) < 3 && scanner.Scan() {
+			if line := scanner.Text(); line == "event: log" {
+				events = append(events, line)
+			}
+		}
+		require.Len(t, events, 3)
+		cancel()
+		res.Body.Close()
+	}
+}
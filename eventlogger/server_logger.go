@@ -2,26 +2,31 @@ package eventlogger
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
+	"fmt"
 	"math"
 	"math/big"
 	"net"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logabi"
 	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logstore"
 	"github.com/bang9ming9/bm-cli-tool/eventlogger/logtypes"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/sirupsen/logrus"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -29,238 +34,645 @@ import (
 type Backend interface {
 	// utils.Backend
 	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
 	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+	// 로그 보강 (Chain.Enrich)
+	BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
 }
 
+// Chain 은 LoggerServer 가 로그를 수집하는 하나의 체인이다.
+type Chain struct {
+	// 0 이 아니면 Client 의 체인 ID 와 같은지 확인한다.
+	ID     uint64
+	Client Backend
+	// Store 는 체인 ID 로 구분된 저장소여야 한다. (logstore.NewMongoStore, logstore.NewGormStore)
+	Store logstore.LogStore
+	// nil 이면 Admin.Start 가 호출될 때까지 수집을 시작하지 않는다.
+	Query *ethereum.FilterQuery
+	// 0 이면 Options 의 값을 사용한다.
+	Confirmations uint64
+	BackfillRange uint64
+	// 설정되면 로그에 블록 시간과 트랜잭션의 from, to, status, gasUsed 를 더해서 저장하고 전달한다.
+	Enrich bool
+}
+
+// Options 는 LoggerServer 의 선택 설정값이다. nil 이면 기본값을 사용한다.
+type Options struct {
+	// 새로운 헤드로부터 Confirmations 만큼 떨어진 블록까지만 수집한다. (Chain 의 기본값)
+	Confirmations uint64
+	// 백필 단계에서 한번의 FilterLogs 로 조회하는 최대 블록 범위 (Chain 의 기본값, 기본값: 2000)
+	BackfillRange uint64
+	// 클라이언트 별 전송 큐의 크기 (기본값: 1024)
+	SendQueueSize int
+	// 전송 큐가 가득 찼을 때의 처리 방법 (기본값: OverflowDisconnect)
+	OverflowPolicy OverflowPolicy
+	// 요청의 decode 가 설정되었을 때 이벤트 디코딩에 사용하는 ABI 목록. nil 이면 빈 목록으로 시작한다. (Admin.AddABI)
+	Registry *logabi.Registry
+
+	// 설정되면 gRPC 서버에 TLS 를 사용한다.
+	TLS *tls.Config
+	// 설정되면 Admin 서비스를 addr 대신 AdminAddr 에서 제공한다.
+	AdminAddr string
+	// Admin 서비스의 인증 방법. 둘 다 설정되지 않으면 인증하지 않는다.
+	AdminToken     string // "authorization: Bearer <token>"
+	AdminMutualTLS bool   // TLS.ClientCAs 로 검증된 클라이언트 인증서
+
+	// 설정되면 Logger 서비스를 HTTPAddr 에서 HTTP(JSON, Server-Sent Events) 로도 제공한다. TLS 가 설정되면 HTTPS 를 사용한다.
+	HTTPAddr string
+	// 설정되면 HTTP 응답의 Access-Control-Allow-Origin 으로 사용한다. (ex: "*")
+	HTTPAllowOrigin string
+
+	// 조건에 맞는 로그를 HTTP POST 로 전달하는 싱크 목록
+	Webhooks []Webhook
+}
+
+// OverflowPolicy 는 클라이언트의 전송 큐가 가득 찼을 때의 처리 방법이다.
+// 어떤 경우에도 로그 수집은 클라이언트를 기다리지 않는다.
+type OverflowPolicy string
+
+const (
+	// 클라이언트의 연결을 끊는다. 클라이언트는 resumeAfter 로 다시 연결할 수 있다.
+	OverflowDisconnect OverflowPolicy = "disconnect"
+	// 로그를 버리고, 다음으로 전달되는 로그의 dropped 에 버려진 로그 수를 알린다.
+	OverflowDrop OverflowPolicy = "drop"
+)
+
+const (
+	defaultSendQueueSize = 1024
+	scanRetryDelay       = 5 * time.Second
+)
+
+var errSlowConsumer = errors.New("send queue overflow, client is too slow")
+
+// LoggerServer 는 요청의 chainId 에 해당하는 체인으로 요청을 전달한다.
+// chainId 가 0 이면 처음으로 등록된 체인(기본 체인)을 사용한다.
 type LoggerServer struct {
 	logger.UnimplementedLoggerServer
 	logger.UnimplementedAdminServer
 	logger *logrus.Entry
 
-	client     Backend
-	collection *mongo.Collection
+	chains   []*chainLogger // 등록된 순서
+	chainOf  map[uint64]*chainLogger
+	registry *logabi.Registry // 모든 체인이 함께 사용한다.
+}
+
+// chainLogger 는 하나의 체인에서 로그를 수집하고, 연결된 클라이언트에게 전달한다.
+type chainLogger struct {
+	logger  *logrus.Entry
+	chainID uint64
+
+	client   Backend
+	store    logstore.LogStore
+	registry *logabi.Registry
+
+	qlock      sync.RWMutex
+	addrSet    map[common.Address]struct{}
+	query      ethereum.FilterQuery
+	queryBlock uint64 // 현재의 주소 목록으로 조회된 마지막 블록
+	backfills  map[common.Address]*addressBackfill
+
+	confirmations uint64
+	backfillRange uint64
+	headers       headerChain
+	recentErrs    recentErrors
+	enricher      *enricher // 보강이 설정되지 않으면 nil
+	sinks         []*webhookSink
 
-	qlock   sync.RWMutex
-	addrSet map[common.Address]struct{}
-	query   ethereum.FilterQuery
+	// scanBlock, stopBlock 은 수집 고루틴과 Admin 호출에서 함께 사용된다.
+	scanBlock  atomic.Uint64
+	checkpoint uint64 // 마지막으로 저장된 체크포인트의 블록 번호, 저장에 실패하면 이 블록부터 다시 수집한다.
+	stopBlock  atomic.Uint64
+	scanStop   chan struct{}
+	runLock    sync.Mutex // Start, Stop, quit 을 직렬화한다.
+	quitted    bool       // quit 이 호출된 뒤에는 다시 시작하지 않는다. (runLock)
+	// Status 가 로그 수집을 멈추지 않고 읽는 상태
+	running  atomic.Bool
+	lastHead atomic.Uint64 // 마지막으로 받은 헤드의 블록 번호
+	metrics  *chainMetrics
 
-	scanBlock uint64
-	stopBlock uint64
-	scanStop  chan struct{}
+	slock          sync.Mutex
+	idCounter      uint32
+	clients        map[uint32]*streamClient
+	sendQueueSize  int
+	overflowPolicy OverflowPolicy
+}
+
+// streamClient 는 Connect 로 연결된 클라이언트이다.
+// 수집된 로그는 queue 에 넣기만 하고, 전송은 클라이언트의 Connect 고루틴이 담당한다.
+type streamClient struct {
+	chainID   uint64
+	addresses map[common.Address]struct{}
+	topics    [][]common.Hash
+	queue     chan logtypes.Log
+	dropped   atomic.Uint64
+	err       chan error
+	decode    func(log types.Log) *logger.DecodedEvent // 디코딩을 요청하지 않았으면 nil
+	connected time.Time
 
-	slock   sync.Mutex
-	streams map[common.Address]*struct {
-		lock      sync.Mutex
-		idCounter uint32
-		clients   map[uint32]struct {
-			sss grpc.ServerStreamingServer[logger.Log]
-			err chan error
+	// 마지막으로 전달된 로그의 위치 (Connect 고루틴에서만 사용한다)
+	sent bool
+	last logtypes.Cursor
+}
+
+// accept 는 마지막으로 전달된 위치를 기준으로 로그의 전달 여부를 결정한다.
+// 이미 전달된 로그는 다시 전달하지 않으며, 전달된 로그가 삭제되면 삭제된 로그의 앞으로 위치를 되돌린다.
+func (c *streamClient) accept(log logtypes.Log) bool {
+	cursor := logtypes.CursorOf(log.Log)
+	if log.Removed {
+		if !c.sent || c.last.Less(cursor) {
+			return false
 		}
+		c.last = cursor.Prev()
+		return true
+	}
+	if c.sent && !c.last.Less(cursor) {
+		return false
 	}
+	c.sent, c.last = true, cursor
+	return true
 }
 
-func NewLoggerServer(stopCh <-chan os.Signal, addr string, log *logrus.Logger, client Backend, collection *mongo.Collection, query *ethereum.FilterQuery) error {
+func NewLoggerServer(stopCh <-chan os.Signal, addr string, log *logrus.Logger, client Backend, store logstore.LogStore, query *ethereum.FilterQuery, options *Options) error {
+	return NewMultiChainLoggerServer(stopCh, addr, log, []Chain{{Client: client, Store: store, Query: query}}, options)
+}
+
+// NewMultiChainLoggerServer 는 여러 체인의 로그를 하나의 gRPC 서버로 제공한다.
+func NewMultiChainLoggerServer(stopCh <-chan os.Signal, addr string, log *logrus.Logger, chains []Chain, options *Options) error {
 	// 입력값 확인
 	if stopCh == nil {
 		return errors.New("stop channel is nil")
 	}
 	if addr == "" {
 		return errors.New("addr is not set")
-	} else {
-		split := strings.Split(addr, ":")
-		if len(split) != 2 {
-			return errors.New("invalid addr require <ip:port>")
-		} else if port, err := strconv.Atoi(split[1]); err != nil {
-			return errors.New("invalid open port: is not number")
-		} else if port < 1000 {
-			return errors.New("invalid open port: require 'port >= 1000'")
-		}
+	} else if err := checkAddr(addr); err != nil {
+		return err
 	}
 	if log == nil {
 		return errors.New("logrus is nil")
 	}
-	if client == nil {
-		return errors.New("chain client is nil")
+	if len(chains) == 0 {
+		return errors.New("chain is not set")
+	}
+	for _, chain := range chains {
+		if chain.Client == nil {
+			return errors.New("chain client is nil")
+		}
+		if chain.Store == nil {
+			return errors.New("log store is nil")
+		}
+	}
+	if options == nil {
+		options = new(Options)
+	}
+	sendQueueSize := options.SendQueueSize
+	if sendQueueSize <= 0 {
+		sendQueueSize = defaultSendQueueSize
+	}
+	overflowPolicy := options.OverflowPolicy
+	switch overflowPolicy {
+	case "":
+		overflowPolicy = OverflowDisconnect
+	case OverflowDisconnect, OverflowDrop:
+	default:
+		return errors.New("invalid overflow policy: " + string(overflowPolicy))
+	}
+	if options.AdminAddr != "" {
+		if err := checkAddr(options.AdminAddr); err != nil {
+			return err
+		}
+	}
+	if options.HTTPAddr != "" {
+		if err := checkAddr(options.HTTPAddr); err != nil {
+			return err
+		}
 	}
-	if collection == nil {
-		return errors.New("mongo collection is nil")
+	if options.AdminMutualTLS && (options.TLS == nil || options.TLS.ClientCAs == nil) {
+		return errors.New("admin mutual tls requires tls with client ca")
 	}
 	// 입력값 확인 끝
 	logentry := log.WithField("module", "LoggerServer")
+	auth := adminAuth{token: options.AdminToken, mutualTLS: options.AdminMutualTLS}
+	if options.TLS == nil && auth.token != "" {
+		logentry.Warn("admin token is sent in plaintext, tls is not set")
+	}
+
+	registry := options.Registry
+	if registry == nil {
+		registry = logabi.NewRegistry()
+	}
+	server := &LoggerServer{
+		logger:   logentry,
+		chains:   make([]*chainLogger, 0, len(chains)),
+		chainOf:  make(map[uint64]*chainLogger),
+		registry: registry,
+	}
+	for _, chain := range chains {
+		chainID, err := chain.Client.ChainID(context.Background())
+		if err != nil {
+			return err
+		}
+		if chain.ID != 0 && chain.ID != chainID.Uint64() {
+			return fmt.Errorf("chain id mismatch: configured %d, client %d", chain.ID, chainID)
+		}
+		if _, ok := server.chainOf[chainID.Uint64()]; ok {
+			return fmt.Errorf("duplicated chain id: %d", chainID)
+		}
+		confirmations, backfillRange := chain.Confirmations, chain.BackfillRange
+		if confirmations == 0 {
+			confirmations = options.Confirmations
+		}
+		if backfillRange == 0 {
+			backfillRange = options.BackfillRange
+		}
+		if backfillRange == 0 {
+			backfillRange = defaultBackfillRange
+		}
+		c := &chainLogger{
+			logger:  logentry.WithField("chain-id", chainID),
+			chainID: chainID.Uint64(),
+
+			client:   chain.Client,
+			store:    chain.Store,
+			registry: registry,
+
+			// qlock: sync.RWMutex{},
+			addrSet: make(map[common.Address]struct{}),
+			// query: ethereum.FilterQuery{},
+			// queryBlock: 0,
+			backfills: make(map[common.Address]*addressBackfill),
+
+			confirmations: confirmations,
+			backfillRange: backfillRange,
+			// headers: headerChain{},
+
+			// scanBlock: 0,
+			// stopBlock: 0,
+			scanStop: make(chan struct{}),
+
+			slock: sync.Mutex{},
+			// idCounter: 0,
+			clients:        make(map[uint32]*streamClient),
+			sendQueueSize:  sendQueueSize,
+			overflowPolicy: overflowPolicy,
+
+			metrics: newChainMetrics(chainID.Uint64()),
+		}
+		if chain.Enrich {
+			c.enricher = newEnricher(chain.Client, chainID)
+		}
+		server.chains = append(server.chains, c)
+		server.chainOf[c.chainID] = c
+	}
+	for _, webhook := range options.Webhooks {
+		c, err := server.chain(webhook.ChainID)
+		if err != nil {
+			return err
+		}
+		for _, sink := range c.sinks {
+			if sink.Name == webhook.Name {
+				return fmt.Errorf("duplicated webhook name: %s (chain-id: %d)", webhook.Name, c.chainID)
+			}
+		}
+		sink, err := newWebhookSink(c.logger, c, webhook)
+		if err != nil {
+			return err
+		}
+		c.sinks = append(c.sinks, sink)
+	}
+
 	// gRPC 서버 Open
 	listener, err := net.Listen("tcp", addr)
 	if err != nil {
 		return err
 	}
-
-	s := grpc.NewServer()
-	server := &LoggerServer{
-		logger: logentry,
-
-		client:     client,
-		collection: collection,
-
-		// qlock: sync.RWMutex{},
-		addrSet: make(map[common.Address]struct{}),
-		// query: ethereum.FilterQuery{},
-
-		// scanBlock: 0,
-		// stopBlock: 0,
-		scanStop: make(chan struct{}),
-
-		slock: sync.Mutex{},
-		streams: make(map[common.Address]*struct {
-			lock      sync.Mutex
-			idCounter uint32
-			clients   map[uint32]struct {
-				sss grpc.ServerStreamingServer[logger.Log]
-				err chan error
-			}
-		}),
+	serverOptions := []grpc.ServerOption{grpc.UnaryInterceptor(auth.interceptor)}
+	if options.TLS != nil {
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(options.TLS)))
 	}
 
-	if query == nil {
-		logentry.Warn("filterquery is not set, waiting for scan start")
-	} else {
-		server.query = *query
-		server.slock.Lock()
-		for _, address := range server.query.Addresses {
-			server.addrSet[address] = struct{}{}
+	s := grpc.NewServer(serverOptions...)
+	admin, adminListener := s, net.Listener(nil)
+	if options.AdminAddr != "" {
+		if adminListener, err = net.Listen("tcp", options.AdminAddr); err != nil {
+			listener.Close()
+			return err
 		}
-		server.slock.Unlock()
-		if err := server.start(query.FromBlock.Uint64()); err != nil {
+		admin = grpc.NewServer(serverOptions...)
+	}
+	var gateway *http.Server
+	if options.HTTPAddr != "" {
+		httpListener, err := net.Listen("tcp", options.HTTPAddr)
+		if err != nil {
+			listener.Close()
+			if adminListener != nil {
+				adminListener.Close()
+			}
 			return err
 		}
+		gateway = &http.Server{Handler: server.gateway(options.HTTPAllowOrigin), TLSConfig: options.TLS}
+		logentry.Info("Starting HTTP gateway on ", options.HTTPAddr)
+		go func() {
+			var err error
+			if options.TLS != nil {
+				err = gateway.ServeTLS(httpListener, "", "")
+			} else {
+				err = gateway.Serve(httpListener)
+			}
+			if !errors.Is(err, http.ErrServerClosed) {
+				logentry.WithField("message", err.Error()).Error("http gateway stopped")
+			}
+		}()
+	}
+
+	for i, c := range server.chains {
+		// 저장된 주소 목록과 설정의 주소 목록을 합친다.
+		query := chains[i].Query
+		if query == nil {
+			if err := c.initAddresses(context.Background(), nil, 0); err != nil {
+				return err
+			}
+			c.logger.Warn("filterquery is not set, waiting for scan start")
+		} else {
+			c.query = *query
+			if err := c.initAddresses(context.Background(), query.Addresses, query.FromBlock.Uint64()); err != nil {
+				return err
+			}
+			if err := c.start(query.FromBlock.Uint64()); err != nil {
+				return err
+			}
+		}
 	}
 
 	logentry.Info("Starting gRPC server on ", addr)
 	logger.RegisterLoggerServer(s, server)
-	logger.RegisterAdminServer(s, server)
+	logger.RegisterAdminServer(admin, server)
+	if adminListener != nil {
+		logentry.Info("Starting gRPC admin server on ", options.AdminAddr)
+		go func() {
+			if err := admin.Serve(adminListener); err != nil {
+				logentry.WithField("message", err.Error()).Error("admin server stopped")
+			}
+		}()
+	}
+
+	sinkCtx, sinkCancel := context.WithCancel(context.Background())
+	for _, c := range server.chains {
+		for _, sink := range c.sinks {
+			go sink.run(sinkCtx)
+		}
+	}
 
 	go func() {
 		<-stopCh
 		logentry.Warn("Quit...")
-		server.quit()
+		sinkCancel()
+		if gateway != nil {
+			gateway.Close()
+		}
+		for _, c := range server.chains {
+			c.quit()
+		}
+		admin.Stop()
 		s.Stop()
 	}()
 
 	return s.Serve(listener)
 }
 
+func checkAddr(addr string) error {
+	split := strings.Split(addr, ":")
+	if len(split) != 2 {
+		return errors.New("invalid addr require <ip:port>")
+	} else if port, err := strconv.Atoi(split[1]); err != nil {
+		return errors.New("invalid open port: is not number")
+	} else if port < 1000 {
+		return errors.New("invalid open port: require 'port >= 1000'")
+	}
+	return nil
+}
+
 // ///////////////////
 // Public Procedure //
 // ///////////////////
 
-func (s *LoggerServer) Info(context.Context, *emptypb.Empty) (*logger.InfoResMessage, error) {
-	s.logger.Debug("Info")
+// info 는 수집중인 주소 목록을 반환한다.
+func (s *chainLogger) info() *logger.ChainInfo {
+	s.qlock.RLock()
+	defer s.qlock.RUnlock()
 
 	addresses := make([][]byte, 0, len(s.addrSet))
 	for a := range s.addrSet {
 		addresses = append(addresses, a.Bytes())
 	}
 
-	return &logger.InfoResMessage{Address: addresses}, nil
+	return &logger.ChainInfo{ChainId: s.chainID, Address: addresses}
 }
 
-func (s *LoggerServer) Connect(req *logger.ConnectReqMessage, stream grpc.ServerStreamingServer[logger.Log]) error {
+func (s *chainLogger) Connect(req *logger.ConnectReqMessage, stream grpc.ServerStreamingServer[logger.Log]) error {
 	s.logger.WithField("req", req).Trace("Connect")
-	address := common.BytesToAddress(req.Address)
+	addresses, topics := make(map[common.Address]struct{}), logtypes.TopicsFromProtobuf(req.Topics)
+	if len(req.Address) != 0 {
+		addresses[common.BytesToAddress(req.Address)] = struct{}{}
+	}
+	for _, address := range req.Addresses {
+		addresses[common.BytesToAddress(address)] = struct{}{}
+	}
+	list := make([]common.Address, 0, len(addresses))
+	for address := range addresses {
+		list = append(list, address)
+	}
 	logentry := s.logger.WithFields(logrus.Fields{
-		"address": address.Hex(),
-		"from":    req.FromBlock,
+		"addresses": list,
+		"from":      req.FromBlock,
+		"topics":    topics,
 	})
 	logentry.Debug("Connect")
-	if _, ok := s.addrSet[address]; !ok {
-		return status.Error(codes.InvalidArgument, "invalid address")
+	if len(list) == 0 {
+		return status.Error(codes.InvalidArgument, "address is not set")
 	}
+	s.qlock.RLock()
+	for _, address := range list {
+		if _, ok := s.addrSet[address]; !ok {
+			s.qlock.RUnlock()
+			return status.Error(codes.InvalidArgument, "invalid address: "+address.Hex())
+		}
+	}
+	s.qlock.RUnlock()
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(stream.Context())
 	defer cancel()
 
-	if req.FromBlock != 0 {
-		fh, fl := logtypes.SplitUint64(req.FromBlock)
-		var filter bson.D = bson.D{
-			{Key: "address", Value: address},
-			{Key: "raw.block_number_high", Value: bson.D{{Key: "$gte", Value: fh}}},
-			{Key: "raw.block_number_low", Value: bson.D{{Key: "$gte", Value: fl}}},
-		}
-		cursor, err := s.collection.Find(ctx, filter)
+	// 히스토리 조회 전에 클라이언트를 등록하여, 조회중에 수집된 로그를 놓치지 않는다.
+	// 조회중에 수집된 로그는 queue 에 쌓이고, 조회가 끝난 뒤 이미 전달된 로그를 제외하고 전달된다.
+	client := &streamClient{chainID: s.chainID, addresses: addresses, topics: topics, queue: make(chan logtypes.Log, s.sendQueueSize), err: make(chan error, 1), connected: time.Now()}
+	if req.Decode {
+		client.decode = s.decode
+	}
+	if req.ResumeAfter != nil {
+		client.sent, client.last = true, logtypes.CursorFromProtobuf(req.ResumeAfter)
+	} else if req.FromBlock != 0 {
+		client.sent, client.last = true, logtypes.Cursor{BlockNumber: req.FromBlock}.Prev()
+	}
+	close := s.addClient(client)
+	defer close()
+
+	// 여러 주소의 로그를 (block, logIndex) 순서로, replayPageSize 만큼씩 나누어 전달한다.
+	for client.sent {
+		after := client.last
+		logs, err := s.store.QueryLogs(ctx, logstore.Query{
+			Addresses: list,
+			Topics:    topics,
+			After:     &after,
+			Limit:     replayPageSize,
+		})
 		if err != nil {
-			if !(errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, mongo.ErrNilDocument)) {
-				return status.Error(codes.Unavailable, "fail to find logs")
-			}
-		} else {
-			defer cursor.Close(ctx)
-			for cursor.Next(ctx) {
-				var result bson.M
-				if err := cursor.Decode(&result); err != nil {
-					return status.Error(codes.Internal, err.Error())
-				} else {
-					if err := stream.Send(logtypes.LogToProtobuf(logtypes.LogFromBsonM(result))); err != nil {
-						logentry.WithField("message", err.Error()).Error("stream send error")
-						return status.Error(codes.Internal, err.Error())
-					}
-				}
+			logentry.WithField("message", err.Error()).Error("fail to query logs")
+			return status.Error(codes.Unavailable, "fail to find logs")
+		}
+		for _, log := range logs {
+			if err := client.send(stream, log); err != nil {
+				logentry.WithField("message", err.Error()).Error("stream send error")
+				return status.Error(codes.Internal, err.Error())
 			}
 		}
+		if len(logs) < replayPageSize {
+			break
+		}
 	}
 
-	close, err := s.addClient(address, stream)
-	defer close()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e := <-client.err:
+			logentry.WithField("message", e.Error()).Warn("disconnect slow client")
+			return status.Error(codes.ResourceExhausted, e.Error())
+		case log := <-client.queue:
+			if err := client.send(stream, log); err != nil {
+				logentry.WithField("message", err.Error()).Error("stream send error")
+				return status.Error(codes.Internal, err.Error())
+			}
+		}
+	}
+}
 
-	select {
-	case <-stream.Context().Done():
+// send 는 아직 전달되지 않은 로그를 전송한다. 버려진 로그가 있다면 그 수를 함께 알린다.
+func (c *streamClient) send(stream grpc.ServerStreamingServer[logger.Log], log logtypes.Log) error {
+	if !c.accept(log) {
 		return nil
-	case e := <-err:
-		return status.Error(codes.Unknown, e.Error())
 	}
+	message := logtypes.LogToProtobuf(log.Log)
+	message.ChainId, message.Dropped = c.chainID, c.dropped.Swap(0)
+	message.Context = logtypes.ContextToProtobuf(log.Context)
+	if c.decode != nil {
+		message.Event = c.decode(log.Log)
+	}
+	return stream.Send(message)
 }
 
-func (s *LoggerServer) addClient(address common.Address, client grpc.ServerStreamingServer[logger.Log]) (func(), <-chan error) {
+func (s *chainLogger) addClient(client *streamClient) func() {
 	s.slock.Lock()
-	if _, ok := s.streams[address]; !ok {
-		s.streams[address] = &struct {
-			lock      sync.Mutex
-			idCounter uint32
-			clients   map[uint32]struct {
-				sss grpc.ServerStreamingServer[logger.Log]
-				err chan error
-			}
-		}{sync.Mutex{}, 0, make(map[uint32]struct {
-			sss grpc.ServerStreamingServer[logger.Log]
-			err chan error
-		})}
-	}
-	stream := s.streams[address]
-	s.slock.Unlock()
-
-	stream.lock.Lock()
-	defer stream.lock.Unlock()
-	stream.idCounter++
-	id, err := stream.idCounter, make(chan error)
-	stream.clients[id] = struct {
-		sss grpc.ServerStreamingServer[logger.Log]
-		err chan error
-	}{client, err}
+	defer s.slock.Unlock()
+	s.idCounter++
+	id := s.idCounter
+	s.clients[id] = client
+	s.metrics.streamClients.Inc()
 
 	return func() {
-		close(err)
+		s.slock.Lock()
+		defer s.slock.Unlock()
+		delete(s.clients, id)
+		s.metrics.streamClients.Dec()
+	}
+}
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+	replayPageSize  = 1000
+)
+
+func (s *chainLogger) GetLogs(ctx context.Context, req *logger.GetLogsReqMessage) (*logger.GetLogsResMessage, error) {
+	s.logger.WithField("req", req).Trace("GetLogs")
+	if req.ToBlock != 0 && req.ToBlock < req.FromBlock {
+		return nil, status.Error(codes.InvalidArgument, "toBlock is less than fromBlock")
+	}
+	pageSize := int64(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	} else if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	// 다음 페이지가 있는지 확인하기 위해 하나를 더 조회한다.
+	query := logstore.Query{
+		Topics:    logtypes.TopicsFromProtobuf(req.Topics),
+		FromBlock: req.FromBlock,
+		ToBlock:   req.ToBlock,
+		Limit:     pageSize + 1,
+	}
+	for _, address := range req.Addresses {
+		query.Addresses = append(query.Addresses, common.BytesToAddress(address))
+	}
+	if req.PageToken != "" {
+		after, err := logtypes.CursorFromToken(req.PageToken)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		query.After = &after
+	}
+	logs, err := s.store.QueryLogs(ctx, query)
+	if err != nil {
+		s.logger.WithField("message", err.Error()).Error("fail to query logs")
+		return nil, status.Error(codes.Unavailable, "fail to find logs")
+	}
+
+	res := new(logger.GetLogsResMessage)
+	for i, log := range logs {
+		if int64(i) == pageSize {
+			res.NextPageToken = logtypes.CursorOf(logs[i-1].Log).Token()
+			break
+		}
+		message := logtypes.LogToProtobuf(log.Log)
+		message.ChainId, message.Context = s.chainID, logtypes.ContextToProtobuf(log.Context)
+		if req.Decode {
+			message.Event = s.decode(log.Log)
+		}
+		res.Logs = append(res.Logs, message)
+	}
+	return res, nil
+}
 
-		stream.lock.Lock()
-		defer stream.lock.Unlock()
-		delete(stream.clients, id)
-	}, err
+// decode 는 등록된 ABI 로 로그를 디코딩한다. 디코딩할 수 없는 로그는 nil 을 반환하며, 원본 로그는 그대로 전달된다.
+func (s *chainLogger) decode(log types.Log) *logger.DecodedEvent {
+	event, err := s.registry.Decode(log)
+	if err != nil {
+		if !errors.Is(err, logabi.ErrUnknownEvent) {
+			s.metrics.observeDecode(err)
+			s.logger.WithFields(logrus.Fields{
+				"address": log.Address,
+				"eventid": log.Topics[0],
+				"message": err.Error(),
+			}).Debug("fail to decode log")
+		}
+		return nil
+	}
+	return logabi.EventToProtobuf(event)
 }
 
 // //////////////////
 // Admin Procedure //
 // //////////////////
 
-func (s *LoggerServer) Add(ctx context.Context, req *logger.AddressReqMessage) (*logger.BlockNumberMessage, error) {
+func (s *chainLogger) Add(ctx context.Context, req *logger.AddressReqMessage) (*logger.BlockNumberMessage, error) {
 	s.logger.WithField("req", req).Trace("Add")
 	address := common.BytesToAddress(req.Address)
 	logentry := s.logger.WithFields(logrus.Fields{
 		"address": address,
+		"from":    req.FromBlock,
 	})
 	logentry.Debug("Add")
 
@@ -269,21 +681,56 @@ func (s *LoggerServer) Add(ctx context.Context, req *logger.AddressReqMessage) (
 
 	if _, ok := s.addrSet[address]; ok {
 		return nil, status.Error(codes.AlreadyExists, address.Hex())
-	} else {
-		s.addrSet[address] = struct{}{}
-		addresses := make([]common.Address, 0, len(s.addrSet))
-		for a := range s.addrSet {
-			addresses = append(addresses, a)
+	}
+
+	// to 블록까지는 추가되기 전의 주소 목록으로 조회되었다.
+	to := s.queryBlock
+	if to == 0 {
+		// 스캔이 시작되지 않았다면 체크포인트 이후부터 조회된다.
+		checkpoint, err := s.store.LatestCheckpoint(ctx)
+		if err != nil {
+			return nil, status.Error(codes.Unavailable, err.Error())
 		}
-		s.query.Addresses = addresses
+		to = checkpoint.BlockNumber
+	}
+	addedBlock := to + 1
+	if req.FromBlock != 0 {
+		addedBlock = min(req.FromBlock, addedBlock)
+	}
+	err := s.store.SaveAddress(ctx, logstore.WatchedAddress{
+		Address:    address,
+		Label:      req.Label,
+		AddedBlock: addedBlock,
+		AddedAt:    time.Now(),
+		Active:     true,
+	})
+	if err != nil {
+		logentry.WithField("message", err.Error()).Error("fail to save address")
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	s.addrSet[address] = struct{}{}
+	addresses := make([]common.Address, 0, len(s.addrSet))
+	for a := range s.addrSet {
+		addresses = append(addresses, a)
+	}
+	s.query.Addresses = addresses
+
+	// 추가 이전의 블록은 다른 주소의 스캔을 멈추지 않고 백그라운드에서 수집한다.
+	if addedBlock <= to {
+		ctx, cancel := context.WithCancel(context.Background())
+		job := &addressBackfill{from: addedBlock, to: to, cancel: cancel}
+		s.backfills[address] = job
+		go s.backfillAddress(ctx, address, job)
 	}
 
 	return &logger.BlockNumberMessage{
-		BlockNumber: s.scanBlock,
+		BlockNumber: s.scanBlock.Load(),
+		ChainId:     s.chainID,
 	}, nil
 }
 
-func (s *LoggerServer) Remove(ctx context.Context, req *logger.AddressReqMessage) (*logger.BlockNumberMessage, error) {
+func (s *chainLogger) Remove(ctx context.Context, req *logger.AddressReqMessage) (*logger.BlockNumberMessage, error) {
 	s.logger.WithField("req", req).Trace("Remove")
 	address := common.BytesToAddress(req.Address)
 	logentry := s.logger.WithFields(logrus.Fields{
@@ -296,39 +743,76 @@ func (s *LoggerServer) Remove(ctx context.Context, req *logger.AddressReqMessage
 
 	if _, ok := s.addrSet[address]; !ok {
 		return nil, status.Error(codes.InvalidArgument, "unknown address")
-	} else {
-		delete(s.addrSet, address)
-		s.addrSet[address] = struct{}{}
-		addresses := make([]common.Address, 0, len(s.addrSet))
-		for a := range s.addrSet {
-			addresses = append(addresses, a)
-		}
-		s.query.Addresses = addresses
 	}
+	if err := s.deactivateAddress(ctx, address); err != nil {
+		logentry.WithField("message", err.Error()).Error("fail to save address")
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	if job, ok := s.backfills[address]; ok {
+		job.cancel()
+		delete(s.backfills, address)
+	}
+	delete(s.addrSet, address)
+	addresses := make([]common.Address, 0, len(s.addrSet))
+	for a := range s.addrSet {
+		addresses = append(addresses, a)
+	}
+	s.query.Addresses = addresses
 
 	return &logger.BlockNumberMessage{
-		BlockNumber: s.scanBlock,
+		BlockNumber: s.scanBlock.Load(),
+		ChainId:     s.chainID,
 	}, nil
 }
 
-func (s *LoggerServer) Start(ctx context.Context, req *logger.BlockNumberMessage) (*emptypb.Empty, error) {
+// List 는 Remove 된 주소를 포함하여 저장된 주소 목록을 추가된 순서로 반환한다.
+func (s *chainLogger) List(ctx context.Context, _ *logger.ChainReqMessage) (*logger.ListResMessage, error) {
+	s.logger.Trace("List")
+	list, err := s.store.LoadAddresses(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	addresses := make([]*logger.WatchedAddress, len(list))
+	for i, w := range list {
+		addresses[i] = watchedAddressToProtobuf(w)
+	}
+	return &logger.ListResMessage{Addresses: addresses}, nil
+}
+
+func (s *chainLogger) Start(ctx context.Context, req *logger.BlockNumberMessage) (*emptypb.Empty, error) {
 	s.logger.WithField("req", req).Trace("Start")
-	// s.scanBlock 는 New...() 또는 Stop() 에서 종료가 완료되면 0으로 설정된다.
-	if s.scanBlock != 0 {
-		err := status.Errorf(codes.Aborted, "already started %v ...", s.scanBlock)
-		s.logger.WithField("message", err.Error()).Error("Start")
-		return nil, err
+	s.runLock.Lock()
+	defer s.runLock.Unlock()
+	if s.quitted {
+		return nil, status.Error(codes.Unavailable, "server is shutting down")
 	}
 	s.logger.WithField("block-number", req.BlockNumber).Debug("Upsert")
 
-	return nil, s.start(req.BlockNumber)
+	if err := s.start(req.BlockNumber); err != nil {
+		s.logger.WithField("message", err.Error()).Error("Start")
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
 }
 
-func (s *LoggerServer) Stop(ctx context.Context, _ *emptypb.Empty) (*logger.BlockNumberMessage, error) {
+func (s *chainLogger) Stop(ctx context.Context, _ *logger.ChainReqMessage) (*logger.BlockNumberMessage, error) {
+	s.logger.Trace("Stop")
+	s.runLock.Lock()
+	defer s.runLock.Unlock()
+	if !s.running.Load() {
+		return nil, status.Error(codes.FailedPrecondition, "not started")
+	}
 	s.stop()
 
+	// 로그 수집이 완료된 마지막 블록을 반환한다.
+	checkpoint, err := s.store.LatestCheckpoint(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
 	return &logger.BlockNumberMessage{
-		BlockNumber: s.stopBlock,
+		BlockNumber: checkpoint.BlockNumber,
+		ChainId:     s.chainID,
 	}, nil
 }
 
@@ -336,15 +820,27 @@ func (s *LoggerServer) Stop(ctx context.Context, _ *emptypb.Empty) (*logger.Bloc
 // Code Reduce //
 // //////////////
 
-func (s *LoggerServer) filterQuery() ethereum.FilterQuery {
-	s.qlock.RLock()
-	defer s.qlock.RUnlock()
+// filterQueryUntil 은 조회 조건을 반환하고, to 블록까지 현재의 주소 목록으로 조회되었음을 기록한다.
+// Admin.Add 는 기록된 블록까지의 과거 로그를 따로 수집한다.
+func (s *chainLogger) filterQueryUntil(to uint64) ethereum.FilterQuery {
+	s.qlock.Lock()
+	defer s.qlock.Unlock()
 
+	s.queryBlock = max(s.queryBlock, to)
 	return s.query
 }
 
-func (s *LoggerServer) start(startBlock uint64) error {
-	s.stopBlock = math.MaxUint64
+// start 는 startBlock 부터 로그 수집을 시작한다. 이미 수집중이면 codes.Aborted 를 반환한다.
+func (s *chainLogger) start(startBlock uint64) (err error) {
+	if !s.running.CompareAndSwap(false, true) {
+		return status.Errorf(codes.Aborted, "already started %v ...", s.scanBlock.Load())
+	}
+	defer func() {
+		if err != nil {
+			s.running.Store(false)
+		}
+	}()
+	s.stopBlock.Store(math.MaxUint64)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -356,83 +852,45 @@ func (s *LoggerServer) start(startBlock uint64) error {
 	}
 
 	{
-		rawBlock := struct {
-			Raw struct {
-				High int64 `bson:"block_number_high"`
-				Low  int64 `bson:"block_number_low"`
-			} `bson:"raw"`
-		}{}
-		err = s.collection.FindOne(ctx, bson.D{}, options.FindOne().SetSort(bson.D{{Key: "raw.block_number_high", Value: -1}, {Key: "raw.block_number_low", Value: -1}})).Decode(&rawBlock)
-		var latestBlock uint64 = 0
+		checkpoint, err := s.store.LatestCheckpoint(ctx)
 		if err != nil {
-			if !(errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, mongo.ErrNilDocument)) {
-				return err
-			}
-		} else {
-			latestBlock = logtypes.JoinUint64(rawBlock.Raw.High, rawBlock.Raw.Low)
+			return err
 		}
+		number, hash := checkpoint.BlockNumber, checkpoint.BlockHash
 		// 스캔이 시작될때 +1 을 하기때문에 startBlock-1 계산
-		s.scanBlock = max(latestBlock, startBlock-1) // DB 에 저장된 값, 입력된 값-1 중에 큰 값을 사용한다.
+		// 체크포인트, 입력된 값-1 중에 큰 값을 사용한다. 입력값이 0 이면 체크포인트부터 이어서 스캔한다.
+		s.scanBlock.Store(number)
+		if startBlock != 0 {
+			s.scanBlock.Store(max(number, startBlock-1))
+		}
+		s.checkpoint = s.scanBlock.Load()
+		// 종료된 동안 발생한 재조직을 감지하기 위해 체크포인트의 블록 해시부터 추적한다.
+		s.headers.reset()
+		if s.checkpoint == number && hash != (common.Hash{}) {
+			s.headers.push(number, hash)
+		}
 	}
 
+	s.metrics.observeScan(s.checkpoint)
 	go func() {
 		defer func() { s.scanStop <- struct{}{} }()
+		defer func() { sub.Unsubscribe() }()
+		s.backfill(newHead)
 		// s.stopBlock 는 start() 가 시작할때 max(uint64), stop() 에서 s.scanBlock+1 으로 설정된다.
-		for s.scanBlock < s.stopBlock {
+		for s.scanBlock.Load() < s.stopBlock.Load() {
 			select {
 			case err := <-sub.Err():
-				s.logger.WithFields(logrus.Fields{
-					"message":    err.Error(),
-					"scan-block": s.scanBlock,
-				}).Panic("err subscribe new head")
+				if err == nil {
+					err = errors.New("subscription closed")
+				}
+				s.fail(s.logger.WithField("scan-block", s.scanBlock.Load()), "subscribe new head", err)
+				// 다시 구독할 때까지 수집이 멈추지만, 다시 구독한 뒤의 헤드로 멈춘 동안의 블록을 모두 수집한다.
+				if sub = s.resubscribe(newHead); sub == nil {
+					return
+				}
 			case head := <-newHead:
-				func(number uint64) {
-					ctx, cancel := context.WithCancel(context.Background())
-					defer cancel()
-
-					documents := []interface{}{}
-					for s.scanBlock < number {
-						s.scanBlock++
-						block := new(big.Int).SetUint64(s.scanBlock)
-
-						filter := s.filterQuery()
-						filter.FromBlock, filter.ToBlock = block, block
-						logentry := s.logger.WithFields(logrus.Fields{
-							"block-number": s.scanBlock,
-							"filter-query": filter,
-						})
-						logentry.Trace()
-
-						logs, err := s.client.FilterLogs(ctx, filter)
-						if err != nil {
-							logentry.WithField("message", err.Error()).Panic("fail to call filter logs")
-						}
-						documents = append(documents, logtypes.LogsToBson(logs)...)
-						for _, log := range logs {
-							logentry.WithFields(logrus.Fields{
-								"address": log.Address,
-								"eventid": log.Topics[0],
-							}).Debug("filter log")
-							if stream, ok := s.streams[log.Address]; ok {
-								stream.lock.Lock()
-								for _, c := range stream.clients {
-									if err := c.sss.Send(logtypes.LogToProtobuf(log)); err != nil {
-										c.err <- err
-									}
-								}
-								stream.lock.Unlock()
-							}
-						}
-					}
-					if len(documents) != 0 {
-						if _, err := s.collection.InsertMany(ctx, documents); err != nil {
-							s.logger.WithFields(logrus.Fields{
-								"document-count": len(documents),
-								"message":        err.Error(),
-							}).Error("fail to insert documents")
-						}
-					}
-				}(head.Number.Uint64())
+				s.observeHead(head.Number.Uint64())
+				s.scan(head.Number.Uint64())
 			}
 		}
 	}()
@@ -440,18 +898,262 @@ func (s *LoggerServer) start(startBlock uint64) error {
 	return nil
 }
 
-func (s *LoggerServer) stop() {
-	s.logger.WithField("scan-block", s.scanBlock).Trace("Stop")
-	s.stopBlock = s.scanBlock + 1
+// scan 은 head 로부터 s.confirmations 만큼 확정된 블록까지 로그를 수집한다.
+func (s *chainLogger) scan(head uint64) {
+	if head < s.confirmations {
+		return
+	}
+	number := head - s.confirmations
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	collected, scanned := []types.Log{}, false
+	for s.scanBlock.Load() < number {
+		// s.scanBlock 은 수집 고루틴에서만 바뀌고, 다른 고루틴은 읽기만 한다.
+		current := s.scanBlock.Load()
+		block := new(big.Int).SetUint64(current + 1)
+		logentry := s.logger.WithField("block-number", block)
+
+		header, err := s.client.HeaderByNumber(ctx, block)
+		if err != nil {
+			// 수집된 블록까지 저장하고, 다음 헤드에서 이어서 수집한다.
+			s.fail(logentry, "header by number", err)
+			break
+		}
+		if parent, ok := s.headers.hash(current); ok && parent != header.ParentHash {
+			// 재조직 감지: 지금까지 수집한 로그를 저장한 뒤 공통 조상까지 되돌린다.
+			logentry.WithFields(logrus.Fields{
+				"parent-hash": header.ParentHash,
+				"scanned":     parent,
+			}).Warn("chain reorg detected")
+			if err := s.commit(ctx, collected); err != nil {
+				return
+			}
+			collected = collected[:0]
+			if err := s.rollback(ctx); err != nil {
+				// 공통 조상을 찾지 못하면 같은 재조직을 바로 다시 감지하기 때문에, 다음 헤드에서 다시 시도한다.
+				break
+			}
+			continue
+		}
+		hash := header.Hash()
+
+		filter := s.filterQueryUntil(current + 1)
+		filter.FromBlock, filter.ToBlock, filter.BlockHash = nil, nil, &hash
+		logentry = logentry.WithField("filter-query", filter)
+		logentry.Trace()
+
+		logs, err := s.filterLogs(ctx, filter)
+		if err != nil {
+			s.fail(logentry, "filter logs", err)
+			break
+		}
+		collected = append(collected, logs...)
+		for _, log := range logs {
+			logentry.WithFields(logrus.Fields{
+				"address": log.Address,
+				"eventid": log.Topics[0],
+			}).Debug("filter log")
+		}
+		s.scanBlock.Store(current + 1)
+		s.headers.push(current+1, hash)
+		s.metrics.blocksScanned.Inc()
+		scanned = true
+	}
+	if scanned {
+		// 저장에 실패하면 되돌린 스캔 블록부터 다음 헤드에서 다시 수집한다.
+		s.commit(ctx, collected)
+	}
+}
+
+// rollback 은 추적중인 블록 해시와 체인의 블록 해시를 비교하여 공통 조상을 찾고,
+// 공통 조상 이후에 저장된 문서를 삭제한 뒤 연결된 클라이언트에게 Removed=true 로그를 전달한다.
+// 노드 호출에 실패하여 공통 조상을 찾지 못하면 아무것도 되돌리지 않고 에러를 반환한다.
+func (s *chainLogger) rollback(ctx context.Context) error {
+	ancestor := s.scanBlock.Load()
+	for ; ancestor > 0; ancestor-- {
+		hash, ok := s.headers.hash(ancestor)
+		if !ok {
+			// 추적 범위를 넘어선 재조직은 추적중인 가장 오래된 블록까지만 되돌린다.
+			s.logger.WithField("block-number", ancestor).Error("reorg is deeper than tracked headers")
+			break
+		}
+		header, err := s.client.HeaderByNumber(ctx, new(big.Int).SetUint64(ancestor))
+		if err != nil {
+			// 되돌리지 않으면 다음 스캔에서 재조직을 다시 감지하고 되돌린다.
+			s.fail(s.logger.WithField("block-number", ancestor), "header by number", err)
+			return err
+		}
+		if header.Hash() == hash {
+			break
+		}
+	}
+	removed := s.headers.truncate(ancestor)
+	logentry := s.logger.WithFields(logrus.Fields{
+		"ancestor":      ancestor,
+		"removed-count": len(removed),
+	})
+	logentry.Warn("rollback")
+
+	s.scanBlock.Store(ancestor)
+	s.checkpoint = min(s.checkpoint, ancestor)
+	if len(removed) == 0 {
+		return nil
+	}
+	ancestorHash, ok := s.headers.hash(ancestor)
+	if !ok {
+		if header, err := s.client.HeaderByNumber(ctx, new(big.Int).SetUint64(ancestor)); err == nil {
+			ancestorHash = header.Hash()
+			s.headers.push(ancestor, ancestorHash)
+		}
+	}
+	// 삭제되는 로그는 저장된 순서의 역순으로 전달한다.
+	removedLogs, err := s.store.QueryLogs(ctx, logstore.Query{FromBlock: ancestor + 1})
+	if err != nil {
+		logentry.WithField("message", err.Error()).Error("fail to find removed logs")
+	}
+	err = s.store.DeleteRange(ctx, ancestor+1, logstore.Checkpoint{BlockNumber: ancestor, BlockHash: ancestorHash})
+	if err != nil {
+		logentry.WithField("message", err.Error()).Error("fail to delete removed logs")
+	} else {
+		s.metrics.observeScan(ancestor)
+	}
+	for _, sink := range s.sinks {
+		sink.rewindTo(ancestor + 1)
+	}
+	// 히스토리 조회와 실시간 전달이 겹치지 않도록 삭제가 완료된 뒤 전달한다.
+	for i := len(removedLogs) - 1; i >= 0; i-- {
+		log := removedLogs[i]
+		log.Removed = true
+		s.broadcast(log)
+	}
+	return nil
+}
+
+// commit 은 수집된 로그들과 현재 스캔 블록의 체크포인트를 함께 저장한 뒤, 연결된 클라이언트에게 로그를 전달한다.
+// 저장이 완료된 뒤 전달하기 때문에 Connect 의 히스토리 조회는 이미 전달된 로그를 놓치지 않는다.
+// 저장에 실패하면 로그를 전달하지 않고, 스캔 블록을 마지막 체크포인트로 되돌린 뒤 에러를 반환한다.
+func (s *chainLogger) commit(ctx context.Context, collected []types.Log) error {
+	logs := s.enrich(ctx, collected)
+	number := s.scanBlock.Load()
+	hash, _ := s.headers.hash(number)
+	if err := s.store.Commit(ctx, logs, logstore.Checkpoint{BlockNumber: number, BlockHash: hash}); err != nil {
+		s.fail(s.logger.WithFields(logrus.Fields{
+			"block-number": number,
+			"log-count":    len(logs),
+		}), "commit logs", err)
+		s.scanBlock.Store(s.checkpoint)
+		s.headers.truncate(s.checkpoint)
+		return err
+	}
+	s.checkpoint = number
+	s.metrics.observeScan(number)
+	s.metrics.observeStored(logs)
+	for _, log := range logs {
+		s.broadcast(log)
+	}
+	if len(logs) != 0 {
+		for _, sink := range s.sinks {
+			sink.notify()
+		}
+	}
+	return nil
+}
+
+// enrich 는 보강이 설정되어 있으면 로그들을 보강한다.
+// 보강에 실패해도 수집은 멈추지 않으며, 보강되지 않은 로그는 Context 없이 저장된다.
+func (s *chainLogger) enrich(ctx context.Context, logs []types.Log) []logtypes.Log {
+	if s.enricher == nil {
+		return logtypes.WithoutContext(logs)
+	}
+	list, err := s.enricher.enrich(ctx, logs)
+	if err != nil {
+		s.fail(s.logger.WithField("log-count", len(logs)), "enrich logs", err)
+	}
+	return list
+}
+
+// fail 은 수집을 멈추지 않는 에러를 로그로 남기고 최근 에러 목록에 기록한다.
+func (s *chainLogger) fail(logentry *logrus.Entry, operation string, err error) {
+	logentry.WithField("message", err.Error()).Error("fail to call " + operation)
+	s.recentErrs.add(operation, err)
+}
+
+// resubscribe 는 구독에 성공하거나 stop() 이 호출될 때까지 scanRetryDelay 마다 새로운 헤드를 다시 구독한다.
+func (s *chainLogger) resubscribe(newHead chan *types.Header) ethereum.Subscription {
+	for {
+		sub, err := s.client.SubscribeNewHead(context.Background(), newHead)
+		if err == nil {
+			return sub
+		}
+		s.fail(s.logger, "subscribe new head", err)
+		if !s.waitRetry() {
+			return nil
+		}
+	}
+}
+
+// waitRetry 는 scanRetryDelay 만큼 기다린다. stop() 이 호출되었다면 기다리지 않고 false 를 반환한다.
+func (s *chainLogger) waitRetry() bool {
+	if s.stopBlock.Load() != math.MaxUint64 {
+		return false
+	}
+	time.Sleep(scanRetryDelay)
+	return s.stopBlock.Load() == math.MaxUint64
+}
+
+func (s *chainLogger) broadcast(log logtypes.Log) {
+	s.slock.Lock()
+	defer s.slock.Unlock()
+	for _, c := range s.clients {
+		if _, ok := c.addresses[log.Address]; !ok || !logtypes.MatchTopics(log.Log, c.topics) {
+			continue
+		}
+		select {
+		case c.queue <- log:
+			continue
+		default:
+		}
+		// 전송 큐가 가득 찼다: 수집을 멈추지 않도록 정책에 따라 처리한다.
+		switch s.overflowPolicy {
+		case OverflowDrop:
+			if c.dropped.Add(1) == 1 {
+				s.logger.WithField("queue-size", cap(c.queue)).Warn("send queue is full, drop logs")
+			}
+		default:
+			select {
+			case c.err <- errSlowConsumer:
+			default:
+			}
+		}
+	}
+}
+
+// stop 은 수집 고루틴이 종료될 때까지 기다린다. 수집중일 때만 호출한다. (runLock)
+func (s *chainLogger) stop() {
+	scanBlock := s.scanBlock.Load()
+	s.logger.WithField("scan-block", scanBlock).Trace("Stop")
+	s.stopBlock.Store(scanBlock + 1)
 	<-s.scanStop
-	s.stopBlock = max(s.scanBlock, s.stopBlock)
-	s.logger.WithField("stop-block", s.stopBlock).Debug("Stop")
-	s.scanBlock = 0
+	stopBlock := max(s.scanBlock.Load(), s.stopBlock.Load())
+	s.stopBlock.Store(stopBlock)
+	s.logger.WithField("stop-block", stopBlock).Debug("Stop")
+	s.scanBlock.Store(0)
+	s.running.Store(false)
 }
 
-func (s *LoggerServer) quit() {
-	if s.scanBlock != 0 {
+func (s *chainLogger) quit() {
+	s.qlock.Lock()
+	for _, job := range s.backfills {
+		job.cancel()
+	}
+	s.qlock.Unlock()
+	s.runLock.Lock()
+	defer s.runLock.Unlock()
+	if s.running.Load() {
 		s.stop()
 	}
+	s.quitted = true
 	close(s.scanStop)
 }
@@ -0,0 +1,131 @@
+package eventlogger
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logtypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// statusHeadTimeout 은 Status 가 노드에 최신 블록을 묻는 최대 시간이다.
+// 시간 안에 응답이 없으면 마지막으로 받은 헤드를 사용한다.
+const statusHeadTimeout = 3 * time.Second
+
+// Status 는 로그 수집의 진행 상태를 반환한다.
+// 수집 고루틴과 공유하는 값은 atomic 과 기존의 잠금으로만 읽기 때문에 로그 수집을 멈추지 않는다.
+func (s *chainLogger) Status(ctx context.Context, _ *logger.ChainReqMessage) (*logger.StatusResMessage, error) {
+	s.logger.Trace("Status")
+
+	checkpoint, err := s.store.LatestCheckpoint(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	list, err := s.store.LoadAddresses(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	counts, err := s.store.CountLogs(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	res := &logger.StatusResMessage{
+		ChainId:       s.chainID,
+		HeadBlock:     s.head(ctx),
+		ScanBlock:     checkpoint.BlockNumber,
+		Running:       s.running.Load(),
+		Confirmations: s.confirmations,
+	}
+	if res.HeadBlock > res.ScanBlock {
+		res.Lag = res.HeadBlock - res.ScanBlock
+	}
+
+	s.qlock.RLock()
+	for _, w := range list {
+		_, backfilling := s.backfills[w.Address]
+		res.Addresses = append(res.Addresses, &logger.AddressStatus{
+			Address:     watchedAddressToProtobuf(w),
+			LogCount:    counts[w.Address],
+			Backfilling: backfilling,
+		})
+	}
+	s.qlock.RUnlock()
+
+	res.Clients = s.clientStatus()
+	for _, e := range s.recentErrs.snapshot() {
+		res.Errors = append(res.Errors, &logger.ErrorStatus{
+			Time:      timestamppb.New(e.Time),
+			Operation: e.Operation,
+			Message:   e.Message,
+		})
+	}
+	if backend, ok := s.client.(interface{ Endpoints() []EndpointStatus }); ok {
+		for _, e := range backend.Endpoints() {
+			endpoint := &logger.EndpointStatus{
+				Uri:       e.URI,
+				Active:    e.Active,
+				Healthy:   e.Healthy,
+				Failures:  uint32(e.Failures),
+				LastError: e.LastError,
+			}
+			if !e.LastErrorAt.IsZero() {
+				endpoint.LastErrorAt = timestamppb.New(e.LastErrorAt)
+			}
+			res.Endpoints = append(res.Endpoints, endpoint)
+		}
+	}
+	for _, sink := range s.sinks {
+		webhook := &logger.WebhookStatus{Name: sink.Name, Url: sink.URL}
+		cursor, ok, err := s.store.LoadCursor(ctx, sink.Name)
+		if err != nil {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		if ok {
+			webhook.Cursor = logtypes.CursorToProtobuf(cursor)
+		}
+		res.Webhooks = append(res.Webhooks, webhook)
+	}
+	return res, nil
+}
+
+// head 는 노드의 최신 블록 번호를 반환한다. 노드를 호출할 수 없으면 마지막으로 받은 헤드를 반환한다.
+func (s *chainLogger) head(ctx context.Context) uint64 {
+	ctx, cancel := context.WithTimeout(ctx, statusHeadTimeout)
+	defer cancel()
+	head, err := s.client.BlockNumber(ctx)
+	if err != nil {
+		s.logger.WithField("message", err.Error()).Debug("fail to get block number, use the last head")
+		return s.lastHead.Load()
+	}
+	return head
+}
+
+// clientStatus 는 연결된 클라이언트들의 상태를 연결된 순서로 반환한다.
+func (s *chainLogger) clientStatus() []*logger.ClientStatus {
+	s.slock.Lock()
+	defer s.slock.Unlock()
+
+	list := make([]*logger.ClientStatus, 0, len(s.clients))
+	for id, c := range s.clients {
+		addresses := make([][]byte, 0, len(c.addresses))
+		for address := range c.addresses {
+			addresses = append(addresses, address.Bytes())
+		}
+		list = append(list, &logger.ClientStatus{
+			Id:            id,
+			Addresses:     addresses,
+			QueueLength:   uint32(len(c.queue)),
+			QueueCapacity: uint32(cap(c.queue)),
+			Dropped:       c.dropped.Load(),
+			ConnectedAt:   timestamppb.New(c.connected),
+			Decode:        c.decode != nil,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Id < list[j].Id })
+	return list
+}
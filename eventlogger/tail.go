@@ -0,0 +1,211 @@
+package eventlogger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/bang9ming9/bm-cli-tool/cmd/flags"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logabi"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logtypes"
+	gov "github.com/bang9ming9/bm-governance/abis"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// tailRetryDelay 는 스트림이 끊어진 뒤 다시 연결하기 전에 기다리는 시간이다.
+const tailRetryDelay = 3 * time.Second
+
+var (
+	tailFromFlag = &cli.Uint64Flag{
+		Name:  "from",
+		Usage: "print stored logs from this block before new logs (default: new logs only)",
+	}
+	abiFlag = &cli.StringSliceFlag{
+		Name:      "abi",
+		TakesFile: true,
+		Usage:     "ABI JSON or hardhat artifact file to decode with, can be repeated (default: BmErc20, BmErc1155, BmGovernor, Faucet)",
+	}
+)
+
+var tailCommand = &cli.Command{
+	Name:      "tail",
+	Usage:     "Print decoded events as they are collected",
+	ArgsUsage: "[address...] (default: all addresses of the chain)",
+	Flags:     []cli.Flag{flags.ConfigFlag, uriFlag, adminChainIDFlag, tailFromFlag, abiFlag, jsonFlag},
+	Action: func(ctx *cli.Context) error {
+		registry, err := tailRegistry(ctx.StringSlice(abiFlag.Name))
+		if err != nil {
+			return err
+		}
+		var addresses [][]byte
+		for _, arg := range ctx.Args().Slice() {
+			if !common.IsHexAddress(arg) {
+				return fmt.Errorf("invalid address: %s", arg)
+			}
+			addresses = append(addresses, common.HexToAddress(arg).Bytes())
+		}
+
+		conn, err := dialLogger(ctx, false)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		client := logger.NewLoggerClient(conn)
+
+		c, stop := signal.NotifyContext(ctx.Context, syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		chainID := ctx.Uint64(adminChainIDFlag.Name)
+		if len(addresses) == 0 {
+			if addresses, err = chainAddresses(c, client, chainID); err != nil {
+				return err
+			}
+		}
+
+		req := &logger.ConnectReqMessage{Addresses: addresses, FromBlock: ctx.Uint64(tailFromFlag.Name), ChainId: chainID}
+		print := tailText
+		if ctx.Bool(jsonFlag.Name) {
+			print = tailJSON
+		}
+		for {
+			err := tail(c, client, req, registry, print)
+			if c.Err() != nil {
+				return nil
+			}
+			// 서버가 재시작되거나 전송 큐가 넘쳐 끊어지면 마지막으로 받은 로그 이후부터 다시 받는다.
+			if code := status.Code(err); code != codes.Unavailable && code != codes.ResourceExhausted {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "disconnected, reconnect in %s: %v\n", tailRetryDelay, err)
+			select {
+			case <-c.Done():
+				return nil
+			case <-time.After(tailRetryDelay):
+			}
+		}
+	},
+}
+
+// tail 은 스트림이 끊어질 때까지 로그를 출력한다. 받은 로그의 위치를 req.ResumeAfter 에 기록한다.
+func tail(ctx context.Context, client logger.LoggerClient, req *logger.ConnectReqMessage, registry *logabi.Registry, print func(w io.Writer, log gatewayLog)) error {
+	stream, err := client.Connect(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		message, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if message.Dropped != 0 {
+			fmt.Fprintf(os.Stderr, "%d logs are dropped by the event-logger\n", message.Dropped)
+		}
+		print(os.Stdout, tailLog(registry, message))
+
+		cursor := logtypes.CursorOf(logtypes.LogFromProtobuf(message))
+		if message.Removed {
+			cursor = cursor.Prev()
+		}
+		req.ResumeAfter = logtypes.CursorToProtobuf(cursor)
+	}
+}
+
+// tailLog 는 서버의 디코딩 대신 tail 의 ABI 로 디코딩한 로그를 반환한다. 디코딩하지 못하면 Event 는 nil 이다.
+func tailLog(registry *logabi.Registry, message *logger.Log) gatewayLog {
+	log := logToGateway(message)
+	event, err := registry.Decode(logtypes.LogFromProtobuf(message))
+	if err != nil {
+		event = nil
+	}
+	log.Event = event
+	return log
+}
+
+// chainAddresses 는 chainID 의 체인에서 수집중인 주소 목록을 반환한다.
+func chainAddresses(ctx context.Context, client logger.LoggerClient, chainID uint64) ([][]byte, error) {
+	info, err := client.Info(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	for i, chain := range info.Chains {
+		if chain.ChainId == chainID || (chainID == 0 && i == 0) {
+			if len(chain.Address) == 0 {
+				return nil, fmt.Errorf("no address is collected on chain %d", chain.ChainId)
+			}
+			return chain.Address, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown chain id: %d", chainID)
+}
+
+// tailRegistry 는 files 의 ABI 를 등록한다. files 가 없으면 bm-governance 컨트랙트의 ABI 를 등록한다.
+func tailRegistry(files []string) (*logabi.Registry, error) {
+	registry := logabi.NewRegistry()
+	if len(files) != 0 {
+		for _, file := range files {
+			if _, err := registry.LoadFile(file, nil); err != nil {
+				return nil, err
+			}
+		}
+		return registry, nil
+	}
+	for _, metadata := range []*bind.MetaData{gov.BmErc20MetaData, gov.BmErc1155MetaData, gov.BmGovernorMetaData, gov.FaucetMetaData} {
+		contractABI, err := metadata.GetAbi()
+		if err != nil {
+			return nil, err
+		}
+		registry.Register(nil, *contractABI)
+	}
+	return registry, nil
+}
+
+// tailText 는 로그 하나를 "<block> <tx> <address> <event>(<name>=<value>, ...)" 형식의 한 줄로 출력한다.
+// 디코딩하지 못한 로그는 topic0 과 data 를 출력한다.
+func tailText(w io.Writer, log gatewayLog) {
+	var line strings.Builder
+	if log.Removed {
+		line.WriteString("removed ")
+	}
+	fmt.Fprintf(&line, "%d %s %s ", log.BlockNumber, log.TxHash.Hex(), log.Address.Hex())
+	if log.Event == nil {
+		topic0 := "-"
+		if len(log.Topics) != 0 {
+			topic0 = log.Topics[0].Hex()
+		}
+		fmt.Fprintf(&line, "unknown(topic0=%s, data=%s)", topic0, log.Data)
+	} else {
+		args := make([]string, len(log.Event.Args))
+		for i, arg := range log.Event.Args {
+			args[i] = arg.Name + "=" + argText(arg.Value)
+		}
+		fmt.Fprintf(&line, "%s(%s)", log.Event.Name, strings.Join(args, ", "))
+	}
+	fmt.Fprintln(w, line.String())
+}
+
+// argText 는 JSON 문자열이면 따옴표 없이, 배열이나 객체는 JSON 그대로 반환한다.
+func argText(value json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(value, &text); err == nil {
+		return text
+	}
+	return string(value)
+}
+
+// tailJSON 은 로그 하나를 HTTP 의 /v1/connect 와 같은 형식의 JSON 한 줄로 출력한다.
+func tailJSON(w io.Writer, log gatewayLog) {
+	data, _ := json.Marshal(log)
+	fmt.Fprintln(w, string(data))
+}
@@ -0,0 +1,146 @@
+package eventlogger_test
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	abis "github.com/bang9ming9/bm-governance/abis"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestTailText(t *testing.T) {
+	registry, err := eventlogger.TailRegistry(nil)
+	require.NoError(t, err)
+	erc20ABI, err := abis.BmErc20MetaData.GetAbi()
+	require.NoError(t, err)
+	transfer := erc20ABI.Events["Transfer"].ID
+
+	from, to := common.HexToAddress("0x14d95b8ecc31875f409c1fe5cd58b3b5cddfddfd"), common.HexToAddress("0x0000000000000000000000000000000000004000")
+	txHash := common.HexToHash("0x01")
+	message := func(removed bool, data []byte, topics ...common.Hash) *logger.Log {
+		list := make([][]byte, len(topics))
+		for i, topic := range topics {
+			list[i] = topic.Bytes()
+		}
+		return &logger.Log{
+			Raw:     &logger.Log_Raw{BlockNumber: 100, TxHash: txHash.Bytes()},
+			Address: erc20.Bytes(), Topics: list, Data: data, Removed: removed, ChainId: 1337,
+		}
+	}
+	value := common.BigToHash(big.NewInt(1000)).Bytes()
+	prefix := "100 " + txHash.Hex() + " " + erc20.Hex() + " "
+
+	tests := []struct {
+		name     string
+		message  *logger.Log
+		expected string
+	}{
+		{
+			name:     "Decoded",
+			message:  message(false, value, transfer, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())),
+			expected: prefix + "Transfer(from=" + from.Hex() + ", to=" + to.Hex() + ", value=1000)",
+		},
+		{
+			name:     "Removed",
+			message:  message(true, value, transfer, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())),
+			expected: "removed " + prefix + "Transfer(from=" + from.Hex() + ", to=" + to.Hex() + ", value=1000)",
+		},
+		{
+			name:     "UnknownEvent",
+			message:  message(false, []byte{0xca, 0xfe}, common.HexToHash("0xdead")),
+			expected: prefix + "unknown(topic0=" + common.HexToHash("0xdead").Hex() + ", data=0xcafe)",
+		},
+		{
+			// 등록된 이벤트이지만 인자가 맞지 않으면 디코딩하지 않는다.
+			name:     "DecodeError",
+			message:  message(false, nil, transfer),
+			expected: prefix + "unknown(topic0=" + transfer.Hex() + ", data=0x)",
+		},
+		{
+			name:     "Anonymous",
+			message:  message(false, []byte{0x01}),
+			expected: prefix + "unknown(topic0=-, data=0x01)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected+"\n", eventlogger.TailText(registry, tt.message))
+		})
+	}
+
+	t.Run("ABIFile", func(t *testing.T) {
+		// --abi 가 설정되면 기본 ABI 를 등록하지 않는다.
+		file := filepath.Join(t.TempDir(), "token.json")
+		require.NoError(t, os.WriteFile(file, []byte(`[{"type":"event","name":"Minted","anonymous":false,"inputs":[{"name":"to","type":"address","indexed":true},{"name":"ids","type":"uint256[]","indexed":false}]}]`), 0644))
+		registry, err := eventlogger.TailRegistry([]string{file})
+		require.NoError(t, err)
+
+		ids := append(common.BigToHash(big.NewInt(32)).Bytes(), common.BigToHash(big.NewInt(2)).Bytes()...)
+		ids = append(ids, common.BigToHash(big.NewInt(1)).Bytes()...)
+		ids = append(ids, common.BigToHash(big.NewInt(2)).Bytes()...)
+		minted := crypto.Keccak256Hash([]byte("Minted(address,uint256[])"))
+		require.Equal(t, prefix+`Minted(to=`+to.Hex()+`, ids=["1","2"])`+"\n",
+			eventlogger.TailText(registry, message(false, ids, minted, common.BytesToHash(to.Bytes()))))
+
+		transferLog := message(false, value, transfer, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes()))
+		require.Equal(t, prefix+"unknown(topic0="+transfer.Hex()+", data=0x"+common.Bytes2Hex(value)+")\n", eventlogger.TailText(registry, transferLog))
+	})
+	t.Run("InvalidABIFile", func(t *testing.T) {
+		_, err := eventlogger.TailRegistry([]string{filepath.Join(t.TempDir(), "missing.json")})
+		require.Error(t, err)
+	})
+}
+
+// infoClient 는 Info 만 응답하는 LoggerClient 이다.
+type infoClient struct {
+	logger.LoggerClient
+	info *logger.InfoResMessage
+}
+
+func (c infoClient) Info(context.Context, *emptypb.Empty, ...grpc.CallOption) (*logger.InfoResMessage, error) {
+	return c.info, nil
+}
+
+func TestChainAddresses(t *testing.T) {
+	client := infoClient{info: &logger.InfoResMessage{
+		ChainId: 1337,
+		Address: [][]byte{erc20.Bytes()},
+		Chains: []*logger.ChainInfo{
+			{ChainId: 1337, Address: [][]byte{erc20.Bytes()}},
+			{ChainId: 1, Address: [][]byte{erc1155.Bytes(), faucet.Bytes()}},
+			{ChainId: 5},
+		},
+	}}
+
+	tests := []struct {
+		name     string
+		chainID  uint64
+		expected [][]byte
+		err      string
+	}{
+		{name: "DefaultChain", chainID: 0, expected: [][]byte{erc20.Bytes()}},
+		{name: "ChainID", chainID: 1, expected: [][]byte{erc1155.Bytes(), faucet.Bytes()}},
+		{name: "NoAddress", chainID: 5, err: "no address is collected on chain 5"},
+		{name: "UnknownChain", chainID: 10, err: "unknown chain id: 10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addresses, err := eventlogger.ChainAddresses(context.Background(), client, tt.chainID)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, addresses)
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package eventlogger
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logabi"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logstore"
+	"github.com/bang9ming9/bm-cli-tool/eventlogger/logtypes"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultWebhookBatchSize  = 100
+	defaultWebhookTimeout    = 10 * time.Second
+	defaultWebhookBackoff    = time.Second
+	defaultWebhookMaxBackoff = time.Minute
+	webhookPollInterval      = 5 * time.Second
+
+	// WebhookSignatureHeader 는 Secret 이 설정된 웹훅의 서명 헤더이다. 값은 "sha256=<hex(HMAC-SHA256(secret, body))>" 이다.
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	// WebhookDeliveryHeader 는 배치의 마지막 로그 위치이다. 재시도된 배치는 같은 값을 가지므로 중복 확인에 사용할 수 있다.
+	WebhookDeliveryHeader = "X-Webhook-Delivery"
+	WebhookNameHeader     = "X-Webhook-Name"
+)
+
+// Webhook 은 조건에 맞는 로그를 HTTP POST 로 전달하는 싱크이다.
+// 저장된 로그를 전달 위치 이후부터 순서대로 전달하고, 응답이 2xx 이면 전달 위치를 저장소에 저장한다.
+// 전달에 실패하면 성공할 때까지 재시도하므로 모든 로그는 한번 이상 전달된다. (재시작하거나 재조직되면 다시 전달될 수 있다)
+// Admin.Add 의 과거 로그(백필)와 재조직으로 삭제된 로그는 전달하지 않는다.
+type Webhook struct {
+	Name      string // 전달 위치를 저장하는 이름, 체인 별로 유일해야 한다.
+	ChainID   uint64 // 0 이면 기본 체인
+	URL       string
+	Secret    string           // 설정되면 요청 본문을 HMAC-SHA256 으로 서명한다. (WebhookSignatureHeader)
+	Addresses []common.Address // 빈 목록은 수집중인 모든 주소
+	Topics    [][]common.Hash  // ethereum.FilterQuery.Topics 와 같은 규칙
+	FromBlock uint64           // 저장된 전달 위치가 없을 때 전달을 시작하는 블록. 0 이면 마지막 체크포인트 이후부터 전달한다.
+	Decode    bool             // 등록된 ABI 로 디코딩한 이벤트를 함께 전달한다.
+
+	BatchSize int           // 한번에 전달하는 최대 로그 수 (기본값: 100)
+	BatchWait time.Duration // 배치가 가득 차지 않았을 때 더 기다리는 시간 (기본값: 기다리지 않음)
+	Timeout   time.Duration // 요청 별 제한 시간 (기본값: 10s)
+	// 재시도 전의 대기 시간. MaxAttempts 는 사용하지 않는다. (기본값: 1s, 최대 1m)
+	Retry RetryPolicy
+}
+
+// WebhookPayload 는 웹훅 요청의 본문이다.
+type WebhookPayload struct {
+	Webhook string       `json:"webhook"`
+	ChainID uint64       `json:"chainId"`
+	Logs    []WebhookLog `json:"logs"`
+}
+
+type WebhookLog struct {
+	logtypes.LogJSON
+	Event *logabi.Event `json:"event,omitempty"` // Webhook.Decode 가 설정되고, 디코딩된 로그에서만 설정된다.
+}
+
+// webhookSink 는 하나의 Webhook 을 전달하는 고루틴의 상태이다.
+type webhookSink struct {
+	Webhook
+	logger *logrus.Entry
+	chain  *chainLogger
+	client *http.Client
+	wake   chan struct{}
+
+	lock   sync.Mutex
+	rewind *logtypes.Cursor // 재조직으로 되돌려야 하는 위치
+}
+
+func newWebhookSink(logentry *logrus.Entry, chain *chainLogger, webhook Webhook) (*webhookSink, error) {
+	if webhook.Name == "" {
+		return nil, errors.New("webhook name is not set")
+	}
+	if u, err := url.Parse(webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("invalid webhook url: " + webhook.URL)
+	}
+	if webhook.BatchSize <= 0 {
+		webhook.BatchSize = defaultWebhookBatchSize
+	}
+	if webhook.Timeout <= 0 {
+		webhook.Timeout = defaultWebhookTimeout
+	}
+	if webhook.Retry.Backoff <= 0 {
+		webhook.Retry.Backoff = defaultWebhookBackoff
+	}
+	if webhook.Retry.MaxBackoff <= 0 {
+		webhook.Retry.MaxBackoff = defaultWebhookMaxBackoff
+	}
+	return &webhookSink{
+		Webhook: webhook,
+		logger:  logentry.WithField("webhook", webhook.Name),
+		chain:   chain,
+		client:  &http.Client{Timeout: webhook.Timeout},
+		wake:    make(chan struct{}, 1),
+	}, nil
+}
+
+// notify 는 새로운 로그가 저장되었음을 알린다. 수집을 기다리게 하지 않는다.
+func (w *webhookSink) notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// rewindTo 는 block 이후의 로그가 재조직으로 바뀌었음을 알린다. 전달 위치가 block 이후라면 block 의 앞으로 되돌린다.
+func (w *webhookSink) rewindTo(block uint64) {
+	w.lock.Lock()
+	cursor := logtypes.Cursor{BlockNumber: block}.Prev()
+	if w.rewind == nil || cursor.Less(*w.rewind) {
+		w.rewind = &cursor
+	}
+	w.lock.Unlock()
+	w.notify()
+}
+
+func (w *webhookSink) run(ctx context.Context) {
+	cursor, ok := w.start(ctx)
+	if !ok {
+		return
+	}
+	w.logger.WithField("cursor", cursor).Info("Start webhook")
+
+	attempt, waited := 0, false
+	for {
+		w.lock.Lock()
+		if w.rewind != nil {
+			if w.rewind.Less(cursor) {
+				w.logger.WithFields(logrus.Fields{"from": cursor, "to": *w.rewind}).Warn("rewind webhook")
+				cursor = *w.rewind
+			}
+			w.rewind = nil
+		}
+		w.lock.Unlock()
+
+		after := cursor
+		logs, err := w.chain.store.QueryLogs(ctx, logstore.Query{
+			Addresses: w.Addresses,
+			Topics:    w.Topics,
+			After:     &after,
+			Limit:     int64(w.BatchSize),
+		})
+		if err != nil {
+			attempt++
+			w.chain.fail(w.logger, "query webhook logs", err)
+			if !w.sleep(ctx, w.Retry.backoff(attempt)) {
+				return
+			}
+			continue
+		}
+		if len(logs) == 0 {
+			// 새로운 로그가 저장될 때까지 기다린다. 알림을 놓쳐도 webhookPollInterval 마다 다시 조회한다.
+			if !w.wait(ctx) {
+				return
+			}
+			continue
+		}
+		if len(logs) < w.BatchSize && w.BatchWait > 0 && !waited {
+			// 배치가 가득 차지 않았다면 BatchWait 만큼 더 기다린 뒤 다시 조회한다.
+			waited = true
+			if !w.sleep(ctx, w.BatchWait) {
+				return
+			}
+			continue
+		}
+
+		if !w.deliver(ctx, logs) {
+			return
+		}
+		cursor, attempt, waited = logtypes.CursorOf(logs[len(logs)-1].Log), 0, false
+		// 저장에 실패해도 전달은 계속한다. 재시작하면 마지막으로 저장된 위치 이후부터 다시 전달된다.
+		if err := w.chain.store.SaveCursor(ctx, w.Name, cursor); err != nil && ctx.Err() == nil {
+			w.chain.fail(w.logger, "save webhook cursor", err)
+		}
+	}
+}
+
+// start 는 저장된 전달 위치를 반환한다. 저장된 위치가 없다면 시작 위치를 저장한다.
+func (w *webhookSink) start(ctx context.Context) (logtypes.Cursor, bool) {
+	for attempt := 1; ; attempt++ {
+		cursor, err := w.initCursor(ctx)
+		if err == nil {
+			return cursor, true
+		}
+		w.chain.fail(w.logger, "load webhook cursor", err)
+		if !w.sleep(ctx, w.Retry.backoff(attempt)) {
+			return logtypes.Cursor{}, false
+		}
+	}
+}
+
+func (w *webhookSink) initCursor(ctx context.Context) (logtypes.Cursor, error) {
+	cursor, ok, err := w.chain.store.LoadCursor(ctx, w.Name)
+	if err != nil || ok {
+		return cursor, err
+	}
+	if w.FromBlock != 0 {
+		cursor = logtypes.Cursor{BlockNumber: w.FromBlock}.Prev()
+	} else {
+		checkpoint, err := w.chain.store.LatestCheckpoint(ctx)
+		if err != nil {
+			return cursor, err
+		}
+		cursor = logtypes.Cursor{BlockNumber: checkpoint.BlockNumber, Index: math.MaxUint32}
+	}
+	return cursor, w.chain.store.SaveCursor(ctx, w.Name, cursor)
+}
+
+// deliver 는 로그들이 전달될 때까지 재시도한다. ctx 가 끝나면 false 를 반환한다.
+func (w *webhookSink) deliver(ctx context.Context, logs []logtypes.Log) bool {
+	payload := WebhookPayload{Webhook: w.Name, ChainID: w.chain.chainID, Logs: make([]WebhookLog, len(logs))}
+	for i, log := range logs {
+		payload.Logs[i].LogJSON = logtypes.LogToJSON(w.chain.chainID, log)
+		if w.Decode {
+			if event := w.chain.decode(log.Log); event != nil {
+				payload.Logs[i].Event = logabi.EventFromProtobuf(event)
+			}
+		}
+	}
+	body, _ := json.Marshal(payload) // 모든 필드는 인코딩할 수 있는 값이다.
+	delivery := logtypes.CursorOf(logs[len(logs)-1].Log).Token()
+
+	logentry := w.logger.WithFields(logrus.Fields{
+		"delivery":  delivery,
+		"log-count": len(logs),
+	})
+	for attempt := 1; ; attempt++ {
+		err := w.post(ctx, body, delivery)
+		if err == nil {
+			logentry.Debug("deliver webhook")
+			return true
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+		w.chain.fail(logentry.WithField("attempt", attempt), "deliver webhook", err)
+		if !w.sleep(ctx, w.Retry.backoff(attempt)) {
+			return false
+		}
+	}
+}
+
+func (w *webhookSink) post(ctx context.Context, body []byte, delivery string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookNameHeader, w.Name)
+	req.Header.Set(WebhookDeliveryHeader, delivery)
+	if w.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, SignWebhook(w.Secret, body))
+	}
+	res, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64*1024))
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status: %s", res.Status)
+	}
+	return nil
+}
+
+// wait 은 새로운 로그가 저장되거나 webhookPollInterval 이 지날 때까지 기다린다. ctx 가 끝나면 false 를 반환한다.
+func (w *webhookSink) wait(ctx context.Context) bool {
+	timer := time.NewTimer(webhookPollInterval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-w.wake:
+		return true
+	case <-timer.C:
+		return true
+	}
+}
+
+func (w *webhookSink) sleep(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// SignWebhook 은 WebhookSignatureHeader 의 값을 반환한다. 수신측은 같은 방법으로 계산한 값과 비교한다.
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
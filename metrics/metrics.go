@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+// Serve 는 addr 에서 GET /metrics 로 Prometheus 기본 레지스트리의 지표를 제공한다.
+// 각 패키지의 지표는 기본 레지스트리에 등록되어 있으며, 반환된 서버는 Close 로 종료한다.
+func Serve(addr string, logentry *logrus.Entry) (*http.Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	server := &http.Server{Handler: Handler()}
+	logentry.Info("Starting metrics server on ", addr)
+	go func() {
+		if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			logentry.WithField("message", err.Error()).Error("metrics server stopped")
+		}
+	}()
+	return server, nil
+}
+
+// Handler 는 GET /metrics 로 Prometheus 기본 레지스트리의 지표를 제공하는 핸들러이다.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", promhttp.Handler())
+	return mux
+}
@@ -8,12 +8,12 @@ import (
 
 	"github.com/bang9ming9/bm-cli-tool/cmd/flags"
 	"github.com/bang9ming9/bm-cli-tool/eventlogger/logger"
+	"github.com/bang9ming9/bm-cli-tool/metrics"
 	"github.com/bang9ming9/bm-cli-tool/scan/dbtypes"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -47,10 +47,22 @@ var Command = &cli.Command{
 		}
 
 		log.Info("Connect EventLogger...")
-		conn, err := grpc.NewClient(config.EventLogger.URI, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		creds, err := config.GetEventLoggerCredentials()
 		if err != nil {
 			return err
 		}
+		conn, err := grpc.NewClient(config.EventLogger.URI, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return err
+		}
+
+		if config.Metrics.Host != "" {
+			server, err := metrics.Serve(config.Metrics.Host, log.WithField("scanner", "Metrics"))
+			if err != nil {
+				return err
+			}
+			defer server.Close()
+		}
 
 		stopCh := make(chan os.Signal, 1)
 		signal.Notify(stopCh, syscall.SIGINT, syscall.SIGTERM)
@@ -6,10 +6,13 @@ import (
 	"github.com/bang9ming9/bm-cli-tool/cmd/flags"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 type ContractConfig struct {
-	FromBlock  uint64         `toml:"from"` // 블록을 스캔할 시작 블럭
+	ChainID    uint64         `toml:"chain-id"` // 컨트랙트가 배포된 체인, 0 이면 event-logger 의 기본 체인
+	FromBlock  uint64         `toml:"from"`     // 블록을 스캔할 시작 블럭
 	Faucet     common.Address `toml:"faucet"`
 	ERC20      common.Address `toml:"erc20"`
 	ERC1155    common.Address `toml:"erc1155"`
@@ -19,6 +22,7 @@ type ContractConfig struct {
 type Config struct {
 	EventLogger struct {
 		URI string `toml:"uri"`
+		CA  string `toml:"ca"` // 설정되면 TLS 로 연결하며, 서버 인증서를 CA 인증서로 검증한다.
 	} `toml:"event-logger"`
 	Contracts ContractConfig `toml:"contracts"`
 	Database  struct {
@@ -28,6 +32,9 @@ type Config struct {
 		User     string `toml:"user"`
 		Password string `toml:"passowrd"`
 	} `toml:"db"`
+	Metrics struct {
+		Host string `toml:"host"` // 설정되면 GET /metrics 로 Prometheus 지표를 제공
+	} `toml:"metrics"`
 }
 
 func GetConfig(ctx *cli.Context) (*Config, error) {
@@ -38,6 +45,13 @@ func GetConfig(ctx *cli.Context) (*Config, error) {
 	return config, nil
 }
 
+func (cfg *Config) GetEventLoggerCredentials() (credentials.TransportCredentials, error) {
+	if cfg.EventLogger.CA == "" {
+		return insecure.NewCredentials(), nil
+	}
+	return credentials.NewClientTLSFromFile(cfg.EventLogger.CA, "")
+}
+
 func (cfg *Config) GetPostgreDns() string {
 	dbConfig := cfg.Database
 	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
@@ -8,6 +8,8 @@ import (
 
 type IRecord interface {
 	Do(log types.Log) func(db *gorm.DB) error
+	// Undo 는 reorg 로 제거된(Removed) 로그에 대해 Do 가 반영한 내용을 되돌린다.
+	Undo(log types.Log) func(db *gorm.DB) error
 }
 
 type Raw struct {
@@ -0,0 +1,52 @@
+package scan
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const metricsNamespace = "scanner"
+
+var (
+	blockMetric = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "block",
+		Help:      "Block number of the last log received from the event-logger.",
+	})
+	logsReceivedMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "logs_received_total",
+		Help:      "Number of logs received from the event-logger per contract address.",
+	}, []string{"address"})
+	flushBatchSizeMetric = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "flush_batch_size",
+		Help:      "Number of records written in a database transaction.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+	})
+	flushDurationMetric = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "flush_duration_seconds",
+		Help:      "Duration of a database transaction.",
+		Buckets:   prometheus.DefBuckets,
+	})
+	decodeFailuresMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "decode_failures_total",
+		Help:      "Number of logs that fail to decode per event.",
+	}, []string{"event"})
+)
+
+// observeDecode 는 디코딩하지 못한 로그를 이벤트 시그니처별로 기록한다.
+// ABI 에 없는 이벤트는 "unknown" 으로 기록한다.
+func observeDecode(log types.Log, aBI *abi.ABI) {
+	label := "unknown"
+	if len(log.Topics) != 0 {
+		if event, err := aBI.EventByID(log.Topics[0]); err == nil {
+			label = event.Sig
+		}
+	}
+	decodeFailuresMetric.WithLabelValues(label).Inc()
+}
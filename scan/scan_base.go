@@ -16,7 +16,9 @@ import (
 )
 
 type IScanner interface {
-	Scan(ctx context.Context, client logger.LoggerClient, fromBlock uint64, tx chan<- func(db *gorm.DB) error) error
+	Address() common.Address
+	EventIDs() []common.Hash
+	Handle(log types.Log, tx chan<- func(db *gorm.DB) error)
 }
 
 type Scanner struct {
@@ -26,10 +28,61 @@ type Scanner struct {
 	logentry *logrus.Entry
 }
 
-func (s *Scanner) Scan(ctx context.Context, client logger.LoggerClient, fromBlock uint64, tx chan<- func(db *gorm.DB) error) error {
+func (s *Scanner) Address() common.Address {
+	return s.address
+}
+
+func (s *Scanner) EventIDs() []common.Hash {
+	eventIDs := make([]common.Hash, 0, len(s.types))
+	for id := range s.types {
+		eventIDs = append(eventIDs, id)
+	}
+	return eventIDs
+}
+
+func (s *Scanner) Handle(log types.Log, tx chan<- func(db *gorm.DB) error) {
+	logentry := s.logentry.WithField("log", log)
+
+	var outType reflect.Type
+	if len(log.Topics) != 0 {
+		outType = s.types[log.Topics[0]]
+	}
+	out, err := parse(log, outType, s.abi)
+	if err != nil {
+		if errors.Is(err, ErrNonTargetedEvent) {
+			logentry.Warn(err.Error())
+		} else {
+			logentry.Error(err.Error())
+			observeDecode(log, s.abi)
+		}
+	} else if log.Removed {
+		// reorg 로 제거된 로그는 이전에 저장한 기록을 되돌린다.
+		tx <- out.Undo(log)
+	} else {
+		tx <- out.Do(log)
+	}
+}
+
+// subscribe 는 모든 스캐너의 주소를 하나의 스트림으로 구독하여,
+// 로그를 (block, logIndex) 순서대로 주소에 해당하는 스캐너에 전달한다.
+func subscribe(ctx context.Context, client logger.LoggerClient, chainID, fromBlock uint64, scanners []IScanner, tx chan<- func(db *gorm.DB) error, logentry *logrus.Entry) error {
+	if len(scanners) == 0 {
+		return nil
+	}
+	byAddress := make(map[common.Address]IScanner, len(scanners))
+	addresses, eventIDs := make([][]byte, 0, len(scanners)), []common.Hash{}
+	for _, scanner := range scanners {
+		byAddress[scanner.Address()] = scanner
+		addresses = append(addresses, scanner.Address().Bytes())
+		// 처리하는 이벤트만 전달받는다.
+		eventIDs = append(eventIDs, scanner.EventIDs()...)
+	}
+
 	stream, err := client.Connect(ctx, &logger.ConnectReqMessage{
+		ChainId:   chainID,
 		FromBlock: fromBlock,
-		Address:   s.address.Bytes(),
+		Addresses: addresses,
+		Topics:    logtypes.TopicsToProtobuf([][]common.Hash{eventIDs}),
 	})
 	if err != nil {
 		return err
@@ -38,24 +91,25 @@ func (s *Scanner) Scan(ctx context.Context, client logger.LoggerClient, fromBloc
 		for {
 			recv, err := stream.Recv()
 			if err != nil {
-				s.logentry.WithField("recv", recv).Error(err.Error())
+				logentry.WithField("recv", recv).Error(err.Error())
 				tx <- func(_ *gorm.DB) error { return err }
+				return
 			}
 			if recv == nil {
 				continue
 			}
+			if recv.Dropped != 0 {
+				// 누락된 로그가 있으면 DB 의 상태를 맞출 수 없다.
+				err := errors.Errorf("%d logs are dropped by event-logger", recv.Dropped)
+				logentry.Error(err.Error())
+				tx <- func(_ *gorm.DB) error { return err }
+				return
+			}
 			log := logtypes.LogFromProtobuf(recv)
-			logentry := s.logentry.WithField("log", log)
-
-			out, err := parse(log, s.types[log.Topics[0]], s.abi)
-			if err != nil {
-				if errors.Is(err, ErrNonTargetedEvent) {
-					logentry.Warn(err.Error())
-				} else {
-					logentry.Error(err.Error())
-				}
-			} else {
-				tx <- out.Do(log)
+			blockMetric.Set(float64(log.BlockNumber))
+			logsReceivedMetric.WithLabelValues(log.Address.Hex()).Inc()
+			if scanner, ok := byAddress[log.Address]; ok {
+				scanner.Handle(log, tx)
 			}
 		}
 	}()
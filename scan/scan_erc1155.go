@@ -55,6 +55,12 @@ func (event *BmErc1155TransferSingle) Do(log types.Log) func(db *gorm.DB) error
 	}
 }
 
+func (event *BmErc1155TransferSingle) Undo(log types.Log) func(db *gorm.DB) error {
+	return func(db *gorm.DB) error {
+		return errors.Wrap(deleteERC1155Transfer(db, log.TxHash, 0), "BmErc1155TransferSingle")
+	}
+}
+
 type BmErc1155TransferBatch gov.BmErc1155TransferBatch
 
 func (event *BmErc1155TransferBatch) Do(log types.Log) func(db *gorm.DB) error {
@@ -80,3 +86,22 @@ func (event *BmErc1155TransferBatch) Do(log types.Log) func(db *gorm.DB) error {
 		return nil
 	}
 }
+
+func (event *BmErc1155TransferBatch) Undo(log types.Log) func(db *gorm.DB) error {
+	return func(db *gorm.DB) error {
+		length := len(event.Ids)
+		for i := 0; i < length; i++ {
+			if err := deleteERC1155Transfer(db, log.TxHash, i); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("BmErc1155TransferBatch[%d]", i))
+			}
+		}
+		return nil
+	}
+}
+
+// deleteERC1155Transfer 는 (tx_hash, index) 기본키에 해당하는 기록을 삭제한다.
+func deleteERC1155Transfer(db *gorm.DB, txHash common.Hash, index int) error {
+	return db.Where(map[string]interface{}{"tx_hash": txHash, "index": index}).
+		Delete(&dbtypes.ERC1155Transfer{}).
+		Error
+}
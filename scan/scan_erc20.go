@@ -49,3 +49,10 @@ func (event *BmErc20Transfer) Do(log types.Log) func(db *gorm.DB) error {
 		return errors.Wrap(db.Create(record).Error, "BmErc20Transfer")
 	}
 }
+
+func (event *BmErc20Transfer) Undo(log types.Log) func(db *gorm.DB) error {
+	return func(db *gorm.DB) error {
+		err := db.Where("tx_hash = ?", log.TxHash).Delete(&dbtypes.ERC20Transfer{}).Error
+		return errors.Wrap(err, "BmErc20Transfer")
+	}
+}
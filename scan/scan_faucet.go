@@ -47,3 +47,10 @@ func (event *FaucetClaimed) Do(log types.Log) func(db *gorm.DB) error {
 		return errors.Wrap(db.Create(record).Error, "FaucetClaimed")
 	}
 }
+
+func (event *FaucetClaimed) Undo(log types.Log) func(db *gorm.DB) error {
+	return func(db *gorm.DB) error {
+		err := db.Where("tx_hash = ?", log.TxHash).Delete(&dbtypes.FaucetClaimed{}).Error
+		return errors.Wrap(err, "FaucetClaimed")
+	}
+}
@@ -1,6 +1,7 @@
 package scan
 
 import (
+	"math/big"
 	"reflect"
 
 	"github.com/bang9ming9/bm-cli-tool/scan/dbtypes"
@@ -59,6 +60,13 @@ func (event *BmGovernorProposalCreated) Do(log types.Log) func(db *gorm.DB) erro
 	}
 }
 
+func (event *BmGovernorProposalCreated) Undo(log types.Log) func(db *gorm.DB) error {
+	return func(db *gorm.DB) error {
+		err := db.Where("tx_hash = ?", log.TxHash).Delete(&dbtypes.GovernorProposal{}).Error
+		return errors.Wrap(err, "BmGovernorProposalCreated")
+	}
+}
+
 type BmGovernorProposalCanceled gov.BmGovernorProposalCanceled
 
 func (event *BmGovernorProposalCanceled) Do(log types.Log) func(db *gorm.DB) error {
@@ -72,6 +80,12 @@ func (event *BmGovernorProposalCanceled) Do(log types.Log) func(db *gorm.DB) err
 	}
 }
 
+func (event *BmGovernorProposalCanceled) Undo(log types.Log) func(db *gorm.DB) error {
+	return func(db *gorm.DB) error {
+		return errors.Wrap(reactivateProposal(db, event.ProposalId), "BmGovernorProposalCanceled")
+	}
+}
+
 type BmGovernorProposalExecuted gov.BmGovernorProposalExecuted
 
 func (event *BmGovernorProposalExecuted) Do(log types.Log) func(db *gorm.DB) error {
@@ -84,3 +98,17 @@ func (event *BmGovernorProposalExecuted) Do(log types.Log) func(db *gorm.DB) err
 		return errors.Wrap(err, "BmGovernorProposalExecuted")
 	}
 }
+
+func (event *BmGovernorProposalExecuted) Undo(log types.Log) func(db *gorm.DB) error {
+	return func(db *gorm.DB) error {
+		return errors.Wrap(reactivateProposal(db, event.ProposalId), "BmGovernorProposalExecuted")
+	}
+}
+
+// reactivateProposal 은 취소/실행 이벤트가 reorg 로 제거되었을 때 제안을 다시 활성 상태로 돌린다.
+func reactivateProposal(db *gorm.DB, proposalId *big.Int) error {
+	return db.Model(&dbtypes.GovernorProposal{}).
+		Where("proposal_id = ?", (*dbtypes.BigInt)(proposalId)).
+		Update("active", true).
+		Error
+}
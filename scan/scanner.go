@@ -60,11 +60,8 @@ func Scan(
 	defer cancel()
 
 	txCH := make(chan func(db *gorm.DB) error, 256)
-	for _, scanner := range scanners {
-		err := scanner.Scan(ctx, client, config.FromBlock, txCH)
-		if err != nil {
-			return err
-		}
+	if err := subscribe(ctx, client, config.ChainID, config.FromBlock, scanners, txCH, log.WithField("scanner", "Subscriber")); err != nil {
+		return err
 	}
 
 	tick, txs := time.NewTicker(1e9), make([]func(db *gorm.DB) error, 0, 256)
@@ -81,6 +78,7 @@ func Scan(
 				break
 			}
 
+			started := time.Now()
 			transaction := db.Begin()
 			for i := 0; i < length; i++ {
 				if err := txs[i](transaction); err != nil {
@@ -91,6 +89,8 @@ func Scan(
 				transaction.Rollback()
 				return err
 			}
+			flushDurationMetric.Observe(time.Since(started).Seconds())
+			flushBatchSizeMetric.Observe(float64(length))
 			txs = txs[length:]
 		}
 	}